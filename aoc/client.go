@@ -0,0 +1,225 @@
+// Package aoc talks to the Advent of Code website on behalf of the runner. It
+// fetches puzzle inputs and examples, caching everything it downloads on disk
+// so that nothing is requested twice, and spacing out its requests so that it
+// never puts much load on the site.
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the address of the Advent of Code website.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// DefaultMinInterval is the least time left between two requests.
+const DefaultMinInterval = 3 * time.Second
+
+// UserAgent identifies the runner to the website, as its maintainers ask of
+// automated tools.
+const UserAgent = "github.com/moorec22/advent_of_code_2024"
+
+// SessionEnvVar is the environment variable holding the session token.
+const SessionEnvVar = "AOC_SESSION"
+
+// lastRequestFile is the file in the cache directory whose modification time
+// records when the last request was made, so requests stay spaced out across
+// separate runs.
+const lastRequestFile = ".last_request"
+
+// ErrNoSession is returned when a request needs a session token, but none was
+// found.
+var ErrNoSession = errors.New("no session token: set " + SessionEnvVar + " or write it to the session file")
+
+// examplePattern matches the first preformatted code block on a puzzle page,
+// which is where the example input is given.
+var examplePattern = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+
+// tagPattern matches HTML tags, used to emphasize parts of an example.
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Client fetches puzzle inputs and examples. Every response is cached in
+// CacheDir, and a cached response is returned without making a request.
+type Client struct {
+	BaseURL  string
+	Session  string
+	CacheDir string
+	// MinInterval is the least time left between two requests.
+	MinInterval time.Duration
+	HTTPClient  *http.Client
+}
+
+// NewClient returns a client for the website at baseURL, using the session
+// token session and caching responses in cacheDir.
+func NewClient(baseURL, session, cacheDir string) *Client {
+	return &Client{
+		BaseURL:     strings.TrimSuffix(baseURL, "/"),
+		Session:     session,
+		CacheDir:    cacheDir,
+		MinInterval: DefaultMinInterval,
+		HTTPClient:  http.DefaultClient,
+	}
+}
+
+// ReadSession returns the session token from the environment, or else from the
+// file at path. Surrounding whitespace is trimmed. Returns ErrNoSession if
+// there is no token in either.
+func ReadSession(path string) (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnvVar)); session != "" {
+		return session, nil
+	}
+	if path == "" {
+		return "", ErrNoSession
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	}
+	if err != nil {
+		return "", err
+	}
+	session := strings.TrimSpace(string(data))
+	if session == "" {
+		return "", ErrNoSession
+	}
+	return session, nil
+}
+
+// IsCached returns true if the input for the day is already cached.
+func (c *Client) IsCached(year, day int) bool {
+	_, err := os.Stat(c.cachePath(year, day, "input.txt"))
+	return err == nil
+}
+
+// Input returns the puzzle input for the day, from the cache if it is there.
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	return c.cached(year, day, "input.txt", func() ([]byte, error) {
+		if c.Session == "" {
+			return nil, ErrNoSession
+		}
+		return c.get(ctx, fmt.Sprintf("/%d/day/%d/input", year, day))
+	})
+}
+
+// Example returns the first example input given on the day's puzzle page, from
+// the cache if it is there.
+func (c *Client) Example(ctx context.Context, year, day int) ([]byte, error) {
+	return c.cached(year, day, "example.txt", func() ([]byte, error) {
+		page, err := c.get(ctx, fmt.Sprintf("/%d/day/%d", year, day))
+		if err != nil {
+			return nil, err
+		}
+		return ParseExample(page)
+	})
+}
+
+// ParseExample returns the first example input in a puzzle page.
+func ParseExample(page []byte) ([]byte, error) {
+	match := examplePattern.FindSubmatch(page)
+	if match == nil {
+		return nil, errors.New("no example found on the puzzle page")
+	}
+	example := html.UnescapeString(tagPattern.ReplaceAllString(string(match[1]), ""))
+	return []byte(example), nil
+}
+
+// cached returns the contents of the named file in the day's cache directory.
+// If it is not cached, fetch is called instead, and its result is cached.
+func (c *Client) cached(year, day int, name string, fetch func() ([]byte, error)) ([]byte, error) {
+	path := c.cachePath(year, day, name)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	data, err = fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return data, os.WriteFile(path, data, 0644)
+}
+
+func (c *Client) cachePath(year, day int, name string) string {
+	return filepath.Join(c.CacheDir, fmt.Sprint(year), fmt.Sprintf("day%02d", day), name)
+}
+
+// get requests path from the website, once enough time has passed since the
+// last request, and returns the body of a successful response.
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	if err := c.waitForTurn(ctx); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req, path)
+}
+
+// do sends req, which requests path, and returns the body of a successful
+// response.
+func (c *Client) do(req *http.Request, path string) ([]byte, error) {
+	req.Header.Set("User-Agent", UserAgent)
+	if c.Session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: not found, the puzzle may not be unlocked yet", path)
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
+		return nil, fmt.Errorf("%s: %s, the session token may have expired", path, resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%s: %s", path, resp.Status)
+	}
+	return body, nil
+}
+
+// waitForTurn waits until MinInterval has passed since the last request made
+// by any client sharing the cache directory, and then records a new request.
+// It returns early with ctx's error if ctx is done first.
+func (c *Client) waitForTurn(ctx context.Context) error {
+	if err := os.MkdirAll(c.CacheDir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(c.CacheDir, lastRequestFile)
+	if info, err := os.Stat(path); err == nil {
+		wait := time.Until(info.ModTime().Add(c.MinInterval))
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		return err
+	}
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
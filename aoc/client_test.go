@@ -0,0 +1,185 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testPage = `<html><body><article>
+<p>For example:</p>
+<pre><code>3   4
+4   3
+<em>2</em>   5
+</code></pre>
+<p>Later on:</p>
+<pre><code>1 &lt; 2</code></pre>
+</article></body></html>`
+
+// newTestServer returns a stand-in for the website, serving the 2024 day 1
+// input and puzzle page, and counting the requests made to it.
+func newTestServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2024/day/1/input", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.UserAgent() != UserAgent {
+			http.Error(w, "unexpected user agent", http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("1 2\n3 4\n"))
+	})
+	mux.HandleFunc("/2024/day/1", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(testPage))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestClient(t *testing.T, baseURL, session string) *Client {
+	t.Helper()
+	client := NewClient(baseURL, session, t.TempDir())
+	client.MinInterval = 0
+	return client
+}
+
+func TestClient_Input(t *testing.T) {
+	server, requests := newTestServer(t)
+	client := newTestClient(t, server.URL+"/", "secret")
+
+	for i := range 2 {
+		got, err := client.Input(context.Background(), 2024, 1)
+		if err != nil {
+			t.Fatalf("Input() error = %v", err)
+		}
+		if string(got) != "1 2\n3 4\n" {
+			t.Errorf("Input() = %q, want %q", got, "1 2\n3 4\n")
+		}
+		if requests.Load() != 1 {
+			t.Errorf("after %d calls to Input(), %d requests were made, want 1", i+1, requests.Load())
+		}
+	}
+	if !client.IsCached(2024, 1) {
+		t.Errorf("IsCached() = false, want true")
+	}
+}
+
+func TestClient_Input_Errors(t *testing.T) {
+	server, _ := newTestServer(t)
+	tests := []struct {
+		name    string
+		session string
+		day     int
+	}{
+		{"no session", "", 1},
+		{"wrong session", "wrong", 1},
+		{"locked puzzle", "secret", 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, server.URL, tt.session)
+			if _, err := client.Input(context.Background(), 2024, tt.day); err == nil {
+				t.Errorf("Input() error = nil, want an error")
+			}
+			if client.IsCached(2024, tt.day) {
+				t.Errorf("IsCached() = true after an error, want false")
+			}
+		})
+	}
+}
+
+func TestClient_Example(t *testing.T) {
+	server, requests := newTestServer(t)
+	client := newTestClient(t, server.URL, "secret")
+	want := "3   4\n4   3\n2   5\n"
+	for range 2 {
+		got, err := client.Example(context.Background(), 2024, 1)
+		if err != nil {
+			t.Fatalf("Example() error = %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("Example() = %q, want %q", got, want)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("%d requests were made, want 1", requests.Load())
+	}
+}
+
+func TestParseExample(t *testing.T) {
+	if _, err := ParseExample([]byte("<p>no examples here</p>")); err == nil {
+		t.Errorf("ParseExample() error = nil, want an error")
+	}
+	got, err := ParseExample([]byte("<pre><code>a &amp;&gt; b\n</code></pre>"))
+	if err != nil {
+		t.Fatalf("ParseExample() error = %v", err)
+	}
+	if string(got) != "a &> b\n" {
+		t.Errorf("ParseExample() = %q, want %q", got, "a &> b\n")
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	server, requests := newTestServer(t)
+	client := newTestClient(t, server.URL, "secret")
+	client.MinInterval = 100 * time.Millisecond
+
+	start := time.Now()
+	if _, err := client.Example(context.Background(), 2024, 1); err != nil {
+		t.Fatalf("Example() error = %v", err)
+	}
+	if _, err := client.Input(context.Background(), 2024, 1); err != nil {
+		t.Fatalf("Input() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < client.MinInterval {
+		t.Errorf("two requests took %v, want at least %v", elapsed, client.MinInterval)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("%d requests were made, want 2", requests.Load())
+	}
+
+	// a new client sharing the cache waits for its turn too
+	other := NewClient(server.URL, "secret", client.CacheDir)
+	other.MinInterval = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := other.Input(ctx, 2024, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Input() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestReadSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session")
+	t.Setenv(SessionEnvVar, "")
+	if _, err := ReadSession(path); !errors.Is(err, ErrNoSession) {
+		t.Errorf("ReadSession() with no file error = %v, want %v", err, ErrNoSession)
+	}
+	if err := os.WriteFile(path, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ReadSession(path); err != nil || got != "from-file" {
+		t.Errorf("ReadSession() = %q, %v, want %q", got, err, "from-file")
+	}
+	t.Setenv(SessionEnvVar, "from-env")
+	if got, err := ReadSession(path); err != nil || got != "from-env" {
+		t.Errorf("ReadSession() = %q, %v, want %q", got, err, "from-env")
+	}
+}
@@ -0,0 +1,92 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Attempt is one answer submitted for a part of a day, and the website's
+// verdict on it.
+type Attempt struct {
+	Year    int           `json:"year"`
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer"`
+	Verdict Verdict       `json:"verdict"`
+	Wait    time.Duration `json:"wait_ns,omitempty"`
+	Time    time.Time     `json:"time"`
+}
+
+// History is every answer submitted, oldest first.
+type History []Attempt
+
+// ReadHistory returns the history saved in the file at path. A missing file is
+// an empty history.
+func ReadHistory(path string) (History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	history := make(History, 0)
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("can't parse %s: %w", path, err)
+	}
+	return history, nil
+}
+
+// Write saves the history to the file at path.
+func (h History) Write(path string) error {
+	data, err := json.MarshalIndent(h, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Check returns an error if answer should not be submitted for the part of
+// the day: if the part has already been solved, if answer has already been
+// found wrong, if it is an integer outside the bounds given by earlier
+// answers that were too high or too low, or if the website asked to wait and
+// the wait is not over at now.
+func (h History) Check(year, day, part int, answer string, now time.Time) error {
+	value, numeric := parseAnswer(answer)
+	for _, attempt := range h {
+		if attempt.Year != year || attempt.Day != day || attempt.Part != part {
+			continue
+		}
+		if attempt.Verdict == VerdictRight {
+			return fmt.Errorf("part %d is already solved, with %s", part, attempt.Answer)
+		}
+		if attempt.Answer == answer && attempt.Verdict.IsWrong() {
+			return fmt.Errorf("%s was already submitted, and was %s", answer, attempt.Verdict)
+		}
+		if wait := attempt.Time.Add(attempt.Wait); attempt.Wait > 0 && now.Before(wait) {
+			return fmt.Errorf("the website asked to wait until %s", wait.Format(time.TimeOnly))
+		}
+		bound, ok := parseAnswer(attempt.Answer)
+		if !numeric || !ok {
+			continue
+		}
+		if attempt.Verdict == VerdictTooHigh && value >= bound {
+			return fmt.Errorf("%s is not below %s, which was too high", answer, attempt.Answer)
+		}
+		if attempt.Verdict == VerdictTooLow && value <= bound {
+			return fmt.Errorf("%s is not above %s, which was too low", answer, attempt.Answer)
+		}
+	}
+	return nil
+}
+
+// parseAnswer returns answer as an integer, and whether it is one.
+func parseAnswer(answer string) (int64, bool) {
+	value, err := strconv.ParseInt(answer, 10, 64)
+	return value, err == nil
+}
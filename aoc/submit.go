@@ -0,0 +1,120 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the website's response to a submitted answer.
+type Verdict string
+
+const (
+	VerdictRight   Verdict = "right"
+	VerdictWrong   Verdict = "wrong"
+	VerdictTooHigh Verdict = "too high"
+	VerdictTooLow  Verdict = "too low"
+	VerdictWait    Verdict = "wait"
+	VerdictNotOpen Verdict = "not open"
+	VerdictUnknown Verdict = "unknown"
+)
+
+// IsWrong returns true if the verdict rules the answer out.
+func (v Verdict) IsWrong() bool {
+	return v == VerdictWrong || v == VerdictTooHigh || v == VerdictTooLow
+}
+
+// SubmitResult is the parsed response to a submitted answer.
+type SubmitResult struct {
+	Verdict Verdict
+	// Wait is how long to wait before submitting again, if the website said.
+	Wait time.Duration
+	// Message is the text of the response, without its markup.
+	Message string
+}
+
+// articlePattern matches the article holding the response's message.
+var articlePattern = regexp.MustCompile(`(?s)<article>(.*?)</article>`)
+
+// waitPattern matches the time left to wait, such as "You have 1m 3s left to
+// wait" or "You have 42s left to wait".
+var waitPattern = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+
+// cooldownPattern matches the wait given along with a wrong answer, such as
+// "please wait one minute before trying again" or "please wait 5 minutes
+// before trying again".
+var cooldownPattern = regexp.MustCompile(`[Pp]lease wait (one|\d+) (minute|second)s? before trying again`)
+
+// Submit posts answer as the answer to the part of the day, and returns the
+// website's verdict.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (SubmitResult, error) {
+	if c.Session == "" {
+		return SubmitResult{}, ErrNoSession
+	}
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	page, err := c.post(ctx, fmt.Sprintf("/%d/day/%d/answer", year, day), form)
+	if err != nil {
+		return SubmitResult{}, err
+	}
+	return ParseSubmitResponse(page), nil
+}
+
+// ParseSubmitResponse returns the verdict given in the response page to a
+// submitted answer.
+func ParseSubmitResponse(page []byte) SubmitResult {
+	text := string(page)
+	if match := articlePattern.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	}
+	message := strings.Join(strings.Fields(tagPattern.ReplaceAllString(text, "")), " ")
+	result := SubmitResult{Verdict: VerdictUnknown, Message: message}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		result.Verdict = VerdictRight
+	case strings.Contains(message, "your answer is too high"):
+		result.Verdict = VerdictTooHigh
+	case strings.Contains(message, "your answer is too low"):
+		result.Verdict = VerdictTooLow
+	case strings.Contains(message, "That's not the right answer"):
+		result.Verdict = VerdictWrong
+	case strings.Contains(message, "You gave an answer too recently"):
+		result.Verdict = VerdictWait
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		result.Verdict = VerdictNotOpen
+	}
+	if match := waitPattern.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		result.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := cooldownPattern.FindStringSubmatch(message); match != nil {
+		n := 1
+		if match[1] != "one" {
+			n, _ = strconv.Atoi(match[1])
+		}
+		unit := time.Minute
+		if match[2] == "second" {
+			unit = time.Second
+		}
+		result.Wait = time.Duration(n) * unit
+	}
+	return result
+}
+
+// post posts form to path on the website, once enough time has passed since
+// the last request, and returns the body of a successful response.
+func (c *Client) post(ctx context.Context, path string, form url.Values) ([]byte, error) {
+	if err := c.waitForTurn(ctx); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req, path)
+}
@@ -0,0 +1,148 @@
+package aoc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// responses are the articles the website answers a submission with, by
+// submitted answer.
+var responses = map[string]string{
+	"11":    `<p>That's the right answer!  You are one gold star closer to finding the Chief Historian.</p>`,
+	"100":   `<p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data.</p>`,
+	"5":     `<p>That's not the right answer; your answer is too low.  <a href="/2024/day/1">[Return to Day 1]</a></p>`,
+	"abc":   `<p>That's not the right answer.  If you're stuck, make sure you're using the full input data.</p>`,
+	"42":    `<p>That's not the right answer.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again.</p>`,
+	"43":    `<p>That's not the right answer; your answer is too high.  Because you have guessed incorrectly 4 times on this puzzle, please wait 5 minutes before trying again.</p>`,
+	"soon":  `<p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 3s left to wait. <a href="/2024/day/1">[Return to Day 1]</a></p>`,
+	"again": `<p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/1">[Return to Day 1]</a></p>`,
+}
+
+// newSubmitServer returns a stand-in for the website, answering submissions
+// for 2024 day 1 part 1 with the matching response.
+func newSubmitServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /2024/day/1/answer", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "log in first", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("level") != "1" {
+			http.Error(w, "unexpected level", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("<html><body><main><article>" + responses[r.FormValue("answer")] + "</article></main></body></html>"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Submit(t *testing.T) {
+	server := newSubmitServer(t)
+	client := newTestClient(t, server.URL, "secret")
+	tests := []struct {
+		answer string
+		want   Verdict
+		wait   time.Duration
+	}{
+		{"11", VerdictRight, 0},
+		{"100", VerdictTooHigh, 0},
+		{"5", VerdictTooLow, 0},
+		{"abc", VerdictWrong, 0},
+		{"42", VerdictWrong, time.Minute},
+		{"43", VerdictTooHigh, 5 * time.Minute},
+		{"soon", VerdictWait, time.Minute + 3*time.Second},
+		{"again", VerdictNotOpen, 0},
+		{"other", VerdictUnknown, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			got, err := client.Submit(context.Background(), 2024, 1, 1, tt.answer)
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+			if got.Verdict != tt.want || got.Wait != tt.wait {
+				t.Errorf("Submit() = %v, %v, want %v, %v", got.Verdict, got.Wait, tt.want, tt.wait)
+			}
+		})
+	}
+}
+
+func TestClient_Submit_Errors(t *testing.T) {
+	server := newSubmitServer(t)
+	for _, session := range []string{"", "wrong"} {
+		client := newTestClient(t, server.URL, session)
+		if _, err := client.Submit(context.Background(), 2024, 1, 1, "11"); err == nil {
+			t.Errorf("Submit() with session %q error = nil, want an error", session)
+		}
+	}
+}
+
+func TestHistory_Check(t *testing.T) {
+	start := time.Date(2024, 12, 1, 5, 0, 0, 0, time.UTC)
+	history := History{
+		{Year: 2024, Day: 1, Part: 1, Answer: "100", Verdict: VerdictTooHigh, Time: start},
+		{Year: 2024, Day: 1, Part: 1, Answer: "5", Verdict: VerdictTooLow, Time: start},
+		{Year: 2024, Day: 1, Part: 1, Answer: "42", Verdict: VerdictWrong, Time: start},
+		{Year: 2024, Day: 1, Part: 2, Answer: "7", Verdict: VerdictRight, Time: start},
+		{Year: 2024, Day: 2, Part: 1, Answer: "1", Verdict: VerdictWait, Wait: time.Minute, Time: start},
+		{Year: 2024, Day: 3, Part: 1, Answer: "1", Verdict: VerdictWrong, Wait: time.Minute, Time: start},
+	}
+	tests := []struct {
+		name    string
+		day     int
+		part    int
+		answer  string
+		now     time.Time
+		wantErr bool
+	}{
+		{"between bounds", 1, 1, "50", start, false},
+		{"known wrong", 1, 1, "42", start, true},
+		{"too high bound", 1, 1, "100", start, true},
+		{"above too high", 1, 1, "150", start, true},
+		{"too low bound", 1, 1, "5", start, true},
+		{"below too low", 1, 1, "-3", start, true},
+		{"not a number", 1, 1, "fifty", start, false},
+		{"already solved", 1, 2, "8", start, true},
+		{"other day", 4, 1, "150", start, false},
+		{"still waiting", 2, 1, "2", start.Add(30 * time.Second), true},
+		{"done waiting", 2, 1, "2", start.Add(time.Minute), false},
+		{"waiting after wrong", 3, 1, "2", start.Add(30 * time.Second), true},
+		{"done waiting after wrong", 3, 1, "2", start.Add(time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := history.Check(2024, tt.day, tt.part, tt.answer, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check(%q) error = %v, wantErr %v", tt.answer, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHistory_ReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	history, err := ReadHistory(path)
+	if err != nil || len(history) != 0 {
+		t.Fatalf("ReadHistory() with no file = %v, %v, want an empty history", history, err)
+	}
+	history = append(history, Attempt{Year: 2024, Day: 1, Part: 1, Answer: "100", Verdict: VerdictTooHigh,
+		Time: time.Date(2024, 12, 1, 5, 0, 0, 0, time.UTC)})
+	if err := history.Write(path); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	got, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != history[0] {
+		t.Errorf("ReadHistory() = %v, want %v", got, history)
+	}
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"advent/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// RegressionThreshold is how much slower, as a fraction, the mean time of a
+// phase must be than in the previous benchmark before it is reported as a
+// regression.
+const RegressionThreshold = 0.1
+
+// BenchResult is the outcome of running one phase of a day several times.
+// Allocations are averaged over the runs.
+type BenchResult struct {
+	Year   int           `json:"year"`
+	Day    int           `json:"day"`
+	Input  string        `json:"input"`
+	Phase  string        `json:"phase"`
+	Runs   int           `json:"runs"`
+	Mean   time.Duration `json:"mean_ns"`
+	P50    time.Duration `json:"p50_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	Allocs uint64        `json:"allocs_per_run"`
+	Bytes  uint64        `json:"bytes_per_run"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// key identifies the day, input and phase that r measures, so that it can be
+// compared against the same phase from an earlier benchmark.
+func (r BenchResult) key() string {
+	return fmt.Sprintf("%d/%d/%s/%s", r.Year, r.Day, r.Input, r.Phase)
+}
+
+// BenchRun is one benchmark saved in the history file.
+type BenchRun struct {
+	Time    time.Time     `json:"time"`
+	Results []BenchResult `json:"results"`
+}
+
+// runBenchmarks benchmarks the days chosen by flags, each phase flags.Bench
+// times, and writes the results to w in the given format, compared against the
+// last saved results. The results are then added to the history file. It
+// returns true if every phase ran without error.
+func runBenchmarks(w io.Writer, flags Flags, opts RunOptions) bool {
+	days := util.DaysInYear(flags.Year)
+	if !flags.All {
+		info, ok := util.LookupDay(flags.Year, flags.Day)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "No solution found for day %d of %d\n", flags.Day, flags.Year)
+			return false
+		}
+		days = []util.DayInfo{info}
+	}
+	results := make([]BenchResult, 0)
+	for _, info := range days {
+		filepath, err := inputFilepath(info, flags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input from stdin: %s\n", err)
+			return false
+		}
+		dayResults := benchDay(info, filepath, flags.Bench, opts)
+		if flags.Input == "-" {
+			os.Remove(filepath)
+			for i := range dayResults {
+				dayResults[i].Input = flags.Input
+			}
+		}
+		results = append(results, dayResults...)
+	}
+
+	history, err := readBenchHistory(flags.BenchFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading benchmark history: %s\n", err)
+		return false
+	}
+	if flags.Format == JSONFormat {
+		encoder := json.NewEncoder(w)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing results: %s\n", err)
+				return false
+			}
+		}
+	} else {
+		printBenchTable(w, results, previousBenchResults(history))
+	}
+	history = append(history, BenchRun{Time: time.Now(), Results: results})
+	if err := writeBenchHistory(flags.BenchFile, history); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving benchmark history: %s\n", err)
+		return false
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// benchDay runs each phase of the day chosen by opts runs times, against the
+// input file designated by filepath. Each part is run on a newly created and
+// prepared solution, so that it can't reuse work cached by an earlier run.
+func benchDay(info util.DayInfo, filepath string, runs int, opts RunOptions) []BenchResult {
+	config, configErr := solutionConfig(info, filepath, opts)
+	newSolution := func() (util.Solution, error) {
+		if configErr != nil {
+			return nil, configErr
+		}
+		var solution util.Solution
+		err := recoverError(func() error {
+			var err error
+			solution, err = info.NewSolution(filepath, config)
+			return err
+		})
+		return solution, err
+	}
+	newPrepared := func() (util.Solution, error) {
+		solution, err := newSolution()
+		if err != nil {
+			return nil, err
+		}
+		_, err = prepare(context.Background(), solution, opts.Timeout)
+		return solution, err
+	}
+	parts := []func(util.Solution) func(context.Context) (util.Answer, error){
+		func(s util.Solution) func(context.Context) (util.Answer, error) { return s.PartOneAnswer },
+		func(s util.Solution) func(context.Context) (util.Answer, error) { return s.PartTwoAnswer },
+	}
+	phases := []string{PartOnePhase, PartTwoPhase}
+
+	result := BenchResult{Year: info.Year, Day: info.Day, Input: filepath}
+	results := []BenchResult{measure(result, ParsePhase, runs, func() (func() error, error) {
+		return func() error {
+			_, err := newSolution()
+			return err
+		}, nil
+	})}
+	if solution, err := newSolution(); err == nil {
+		if _, ok := solution.(util.Preparer); ok {
+			results = append(results, measure(result, PreparePhase, runs, func() (func() error, error) {
+				solution, err := newSolution()
+				if err != nil {
+					return nil, err
+				}
+				return func() error {
+					_, err := prepare(context.Background(), solution, opts.Timeout)
+					return err
+				}, nil
+			}))
+		}
+	}
+	for i, part := range parts {
+		if !opts.runsPart(i + 1) {
+			continue
+		}
+		results = append(results, measure(result, phases[i], runs, func() (func() error, error) {
+			solution, err := newPrepared()
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				var answer util.Answer
+				return runPart(context.Background(), part(solution), &answer, opts.Timeout)
+			}, nil
+		}))
+	}
+	return results
+}
+
+// measure runs a phase runs times, and returns result filled in with the
+// times and allocations taken. Before each run, prepare is called to set up
+// the run, and return the function to time, which is run with pprof labels
+// naming the day and phase. Measuring stops at the first error.
+func measure(result BenchResult, phase string, runs int, prepare func() (func() error, error)) BenchResult {
+	result.Phase = phase
+	labels := phaseLabels(result.Year, result.Day, phase)
+	times := make([]time.Duration, 0, runs)
+	var allocs, bytes uint64
+	var before, after runtime.MemStats
+	for range runs {
+		run, err := prepare()
+		if err == nil {
+			pprof.Do(context.Background(), labels, func(context.Context) {
+				runtime.ReadMemStats(&before)
+				start := time.Now()
+				err = recoverError(run)
+				times = append(times, time.Since(start))
+				runtime.ReadMemStats(&after)
+			})
+			allocs += after.Mallocs - before.Mallocs
+			bytes += after.TotalAlloc - before.TotalAlloc
+		}
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+	}
+	result.Runs = len(times)
+	if result.Runs == 0 {
+		return result
+	}
+	var total time.Duration
+	for _, t := range times {
+		total += t
+	}
+	slices.Sort(times)
+	result.Mean = total / time.Duration(result.Runs)
+	result.P50 = percentile(times, 50)
+	result.P95 = percentile(times, 95)
+	result.Allocs = allocs / uint64(result.Runs)
+	result.Bytes = bytes / uint64(result.Runs)
+	return result
+}
+
+// percentile returns the pth percentile of sorted, using the nearest rank.
+// sorted must not be empty.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// readBenchHistory returns the benchmarks saved in the history file at path.
+// A missing file is an empty history.
+func readBenchHistory(path string) ([]BenchRun, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []BenchRun{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	history := make([]BenchRun, 0)
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("can't parse %s: %w", path, err)
+	}
+	return history, nil
+}
+
+// writeBenchHistory saves history to the history file at path.
+func writeBenchHistory(path string, history []BenchRun) error {
+	data, err := json.MarshalIndent(history, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// previousBenchResults returns the latest successful result saved in history
+// for each day, input and phase.
+func previousBenchResults(history []BenchRun) map[string]BenchResult {
+	previous := make(map[string]BenchResult)
+	for _, run := range history {
+		for _, result := range run.Results {
+			if result.Error == "" && result.Runs > 0 {
+				previous[result.key()] = result
+			}
+		}
+	}
+	return previous
+}
+
+// compareBench returns how result compares against previous, the same phase
+// from an earlier benchmark: the change in mean time, and whether it is a
+// regression.
+func compareBench(result, previous BenchResult) (string, bool) {
+	if previous.Mean <= 0 {
+		return "new", false
+	}
+	change := float64(result.Mean-previous.Mean) / float64(previous.Mean)
+	return fmt.Sprintf("%+.1f%%", change*100), change > RegressionThreshold
+}
+
+// printBenchTable writes a table of results to w, one row per phase, each
+// compared against the matching result in previous.
+func printBenchTable(w io.Writer, results []BenchResult, previous map[string]BenchResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tPhase\tRuns\tMean\tp50\tp95\tAllocs\tBytes\tvs previous")
+	regressions := 0
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Fprintf(tw, "%d\t%s\t%d\tERROR\t\t\t\t\t\n", result.Day, result.Phase, result.Runs)
+			continue
+		}
+		comparison, regressed := "new", false
+		if prev, ok := previous[result.key()]; ok {
+			comparison, regressed = compareBench(result, prev)
+		}
+		if regressed {
+			comparison += " REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t%s\n", result.Day, result.Phase, result.Runs,
+			formatDuration(result.Mean), formatDuration(result.P50), formatDuration(result.P95),
+			result.Allocs, result.Bytes, comparison)
+	}
+	tw.Flush()
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Fprintf(w, "Day %d %s: %s\n", result.Day, result.Phase, result.Error)
+		}
+	}
+	if regressions > 0 {
+		fmt.Fprintf(w, "%d phases are more than %.0f%% slower than the previous benchmark\n",
+			regressions, RegressionThreshold*100)
+	}
+}
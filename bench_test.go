@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 20)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	tests := []struct {
+		sorted []time.Duration
+		p      int
+		want   time.Duration
+	}{
+		{sorted, 50, 10 * time.Millisecond},
+		{sorted, 95, 19 * time.Millisecond},
+		{sorted, 100, 20 * time.Millisecond},
+		{sorted, 0, time.Millisecond},
+		{sorted[:1], 95, time.Millisecond},
+		{sorted[:3], 50, 2 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %d) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	prepared := 0
+	result := measure(BenchResult{Day: 1}, PartOnePhase, 4, func() (func() error, error) {
+		prepared++
+		return func() error {
+			time.Sleep(time.Millisecond)
+			return nil
+		}, nil
+	})
+	if prepared != 4 {
+		t.Errorf("measure() prepared %d runs, want 4", prepared)
+	}
+	if result.Runs != 4 || result.Phase != PartOnePhase || result.Error != "" {
+		t.Errorf("measure() = %+v, want 4 runs of %s without error", result, PartOnePhase)
+	}
+	if result.Mean < time.Millisecond || result.P50 > result.P95 {
+		t.Errorf("measure() times = mean %v, p50 %v, p95 %v", result.Mean, result.P50, result.P95)
+	}
+}
+
+func TestMeasure_StopsAtError(t *testing.T) {
+	runs := 0
+	result := measure(BenchResult{}, PartTwoPhase, 5, func() (func() error, error) {
+		return func() error {
+			runs++
+			if runs == 2 {
+				return errors.New("no answer")
+			}
+			return nil
+		}, nil
+	})
+	if runs != 2 || result.Error != "no answer" {
+		t.Errorf("measure() ran %d times with error %q, want 2 with %q", runs, result.Error, "no answer")
+	}
+}
+
+func TestCompareBench(t *testing.T) {
+	previous := BenchResult{Mean: 100 * time.Millisecond}
+	tests := []struct {
+		mean          time.Duration
+		want          string
+		wantRegressed bool
+	}{
+		{100 * time.Millisecond, "+0.0%", false},
+		{105 * time.Millisecond, "+5.0%", false},
+		{120 * time.Millisecond, "+20.0%", true},
+		{50 * time.Millisecond, "-50.0%", false},
+	}
+	for _, tt := range tests {
+		got, regressed := compareBench(BenchResult{Mean: tt.mean}, previous)
+		if got != tt.want || regressed != tt.wantRegressed {
+			t.Errorf("compareBench(%v) = %s, %t, want %s, %t", tt.mean, got, regressed, tt.want, tt.wantRegressed)
+		}
+	}
+}
+
+func TestPreviousBenchResults(t *testing.T) {
+	older := BenchResult{Year: 2024, Day: 1, Input: "input", Phase: ParsePhase, Runs: 3, Mean: time.Second}
+	newer := older
+	newer.Mean = 2 * time.Second
+	failed := older
+	failed.Mean = 0
+	failed.Error = "no such file"
+	history := []BenchRun{
+		{Results: []BenchResult{older}},
+		{Results: []BenchResult{newer}},
+		{Results: []BenchResult{failed}},
+	}
+	previous := previousBenchResults(history)
+	if got := previous[older.key()]; got.Mean != newer.Mean {
+		t.Errorf("previousBenchResults() mean = %v, want %v", got.Mean, newer.Mean)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"advent/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"time"
+)
+
+// DefaultConfigFile is the config file read from the working directory, unless
+// ConfigEnvVar names another.
+const DefaultConfigFile = "advent.json"
+
+// ConfigEnvVar is the environment variable holding the path to the config
+// file.
+const ConfigEnvVar = "ADVENT_CONFIG"
+
+// Config holds the defaults read from the config file. Every field is
+// optional, and flags given on the command line override it.
+type Config struct {
+	Year int `json:"year"`
+	// InputRoot is the directory holding each day's real input, in the same
+	// layout as the module, such as day01/files/input.txt.
+	InputRoot string `json:"input_root"`
+	Format    string `json:"format"`
+	// Timeout is a duration such as "30s".
+	Timeout  string `json:"timeout"`
+	Workers  int    `json:"workers"`
+	CacheDir string `json:"cache_dir"`
+	// Params overrides the parameters of each day, keyed by the day's
+	// directory, such as "day14", and then by input name, as in the day's
+	// DayInfo.Params.
+	Params map[string]map[string]util.Params `json:"params"`
+
+	// timeout is Timeout, parsed.
+	timeout time.Duration
+}
+
+// defaultConfig returns the config used when there is no config file, or for
+// anything the config file leaves out.
+func defaultConfig() Config {
+	return Config{
+		Year:     util.DefaultYear,
+		Format:   TextFormat,
+		Workers:  runtime.NumCPU(),
+		CacheDir: defaultCacheDir(),
+	}
+}
+
+// configPath returns the path to the config file.
+func configPath() string {
+	if path := os.Getenv(ConfigEnvVar); path != "" {
+		return path
+	}
+	return DefaultConfigFile
+}
+
+// readConfig returns the config in the file at path, on top of the defaults. A
+// missing file is an empty config. Fields the config doesn't know are an
+// error, so that typos are caught.
+func readConfig(path string) (Config, error) {
+	config := defaultConfig()
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("can't parse %s: %w", path, err)
+	}
+	if config.Timeout != "" {
+		config.timeout, err = time.ParseDuration(config.Timeout)
+		if err != nil {
+			return Config{}, fmt.Errorf("can't parse %s: timeout: %w", path, err)
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"advent/util"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadConfig(t *testing.T) {
+	config, err := readConfig(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("readConfig() with no file error = %v", err)
+	}
+	if config.Year != util.DefaultYear || config.Format != TextFormat || config.Workers <= 0 {
+		t.Errorf("readConfig() with no file = %+v, want the defaults", config)
+	}
+
+	config, err = readConfig(writeConfig(t, `{
+		"year": 2023,
+		"input_root": "../inputs",
+		"timeout": "30s",
+		"params": {"day14": {"input": {"width": 11}}}
+	}`))
+	if err != nil {
+		t.Fatalf("readConfig() error = %v", err)
+	}
+	if config.Year != 2023 || config.InputRoot != "../inputs" || config.timeout != 30*time.Second {
+		t.Errorf("readConfig() = %+v, want year 2023, input root ../inputs and timeout 30s", config)
+	}
+	if config.Format != TextFormat {
+		t.Errorf("readConfig() format = %q, want the default %q", config.Format, TextFormat)
+	}
+	if !maps.Equal(config.Params["day14"]["input"], util.Params{"width": 11}) {
+		t.Errorf("readConfig() params = %v, want width=11 for day14's input", config.Params)
+	}
+
+	for _, contents := range []string{`{"yeer": 2023}`, `{"timeout": "soon"}`, `{`} {
+		if _, err := readConfig(writeConfig(t, contents)); err == nil {
+			t.Errorf("readConfig(%s) error = nil, want an error", contents)
+		}
+	}
+}
+
+func TestSolutionConfig_Params(t *testing.T) {
+	info := util.DayInfo{Year: util.DefaultYear, Day: 14, Params: map[string]util.Params{
+		"input": {"width": 101, "height": 103},
+		"test":  {"width": 11, "height": 7},
+	}}
+	dayParams := map[string]map[string]util.Params{"day14": {"input": {"width": 50, "height": 60}}}
+	tests := []struct {
+		path   string
+		params util.Params
+		want   util.Params
+	}{
+		{"day14/files/input.txt", nil, util.Params{"width": 50, "height": 60}},
+		{"day14/files/input.txt", util.Params{"width": 5}, util.Params{"width": 5, "height": 60}},
+		// the config's parameters for the real input leave the examples alone
+		{"day14/files/test.txt", nil, util.Params{"width": 11, "height": 7}},
+		{"day14/files/test2.txt", util.Params{"width": 5}, util.Params{"width": 5, "height": 7}},
+	}
+	for _, tt := range tests {
+		opts := RunOptions{DayParams: dayParams, Params: tt.params}
+		config, err := solutionConfig(info, tt.path, opts)
+		if err != nil {
+			t.Fatalf("solutionConfig(%s) error = %v", tt.path, err)
+		}
+		if !maps.Equal(config.Params, tt.want) {
+			t.Errorf("solutionConfig(%s, %v) params = %v, want %v", tt.path, tt.params, config.Params, tt.want)
+		}
+	}
+}
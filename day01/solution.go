@@ -6,6 +6,7 @@ package day01
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
 	"sort"
 )
@@ -17,6 +18,17 @@ type Day01Solution struct {
 	right []int
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   1,
+		Title: "Historian Hysteria",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay01Solution(filepath)
+		},
+	})
+}
+
 func NewDay01Solution(filepath string) (*Day01Solution, error) {
 	left, right, err := getLists(filepath)
 	if err != nil {
@@ -27,15 +39,15 @@ func NewDay01Solution(filepath string) (*Day01Solution, error) {
 	return &Day01Solution{left, right}, nil
 }
 
-func (s *Day01Solution) PartOneAnswer() (int, error) {
+func (s *Day01Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	answer := 0
 	for i := 0; i < len(s.left); i++ {
 		answer += util.IntAbs(s.left[i] - s.right[i])
 	}
-	return answer, nil
+	return util.NewIntAnswer(answer), nil
 }
 
-func (s *Day01Solution) PartTwoAnswer() (int, error) {
+func (s *Day01Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	frequencies := s.getFrequencies(s.right)
 	answer := 0
 	for _, i := range s.left {
@@ -45,7 +57,7 @@ func (s *Day01Solution) PartTwoAnswer() (int, error) {
 		}
 		answer += i * frequency
 	}
-	return answer, nil
+	return util.NewIntAnswer(answer), nil
 }
 
 // getFrequencies returns a map where keys are values in `a`, and values are
@@ -6,25 +6,46 @@ package day02
 import (
 	"advent/util"
 	"bufio"
-	"fmt"
+	"context"
+	"log/slog"
 	"strconv"
 	"strings"
 )
 
 type Day02Solution struct {
 	filepath string
+	logger   *slog.Logger
 }
 
-func NewDay02Solution(filepath string) (*Day02Solution, error) {
-	return &Day02Solution{filepath}, nil
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   2,
+		Title: "Red-Nosed Reports",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay02Solution(filepath, config.Logger)
+		},
+	})
 }
 
-func (s *Day02Solution) PartOneAnswer() (int, error) {
-	return s.safeCount(s.filepath, false)
+func NewDay02Solution(filepath string, logger *slog.Logger) (*Day02Solution, error) {
+	return &Day02Solution{filepath, logger}, nil
 }
 
-func (s *Day02Solution) PartTwoAnswer() (int, error) {
-	return s.safeCount(s.filepath, true)
+func (s *Day02Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	count, err := s.safeCount(s.filepath, false)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(count), nil
+}
+
+func (s *Day02Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	count, err := s.safeCount(s.filepath, true)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(count), nil
 }
 
 // safeCount returns the number of safe number lists in the file at filepath. A
@@ -42,7 +63,7 @@ func (s *Day02Solution) safeCount(filepath string, problemDampener bool) (int, e
 			}
 			numberList, err := s.getNumberList(line)
 			if err != nil {
-				fmt.Printf("Error getting number list for line %s: %s\n", line, err)
+				s.logger.Warn("skipping line that is not a number list", "line", line, "err", err)
 				continue
 			}
 			if s.isSafe(numberList, problemDampener) {
@@ -0,0 +1,96 @@
+package interpreter
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatchers_NextMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher Matcher
+		input   string
+		want    []int
+	}{
+		{"multiply", NewMultiplyMatcher(), "xmul(2,4)%&mul[3,7]", []int{1, 9}},
+		{"multiply with spaces", NewMultiplyMatcher(), "mul ( 2 , 4 )", nil},
+		{"multiply with junk", NewMultiplyMatcher(), "mul(4*, mul(6,9!, ?(12,34)", nil},
+		{"do", NewDoMatcher(), "undo()?mul(8,5))", []int{2, 6}},
+		{"do not don't", NewDoMatcher(), "don't()", nil},
+		{"don't", NewDontMatcher(), "^don't()_mul(5,5)", []int{1, 8}},
+		{"no match", NewDontMatcher(), "do()", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.NextMatch(tt.input); !slices.Equal(got, tt.want) {
+				t.Errorf("NextMatch(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchers_IsMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher Matcher
+		input   string
+		want    bool
+	}{
+		{"exact multiply", NewMultiplyMatcher(), "mul(11,8)", true},
+		{"multiply with prefix", NewMultiplyMatcher(), "xmul(11,8)", false},
+		{"multiply with suffix", NewMultiplyMatcher(), "mul(11,8))", false},
+		{"exact do", NewDoMatcher(), "do()", true},
+		{"exact don't", NewDontMatcher(), "don't()", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.isMatch(tt.input); got != tt.want {
+				t.Errorf("isMatch(%q) = %t, want %t", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiplyMatcher_Parse(t *testing.T) {
+	instruction, err := NewMultiplyMatcher().Parse("mul(32,64)")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	state := instruction.Execute(NewProgramState())
+	if state.Answer != 2048 {
+		t.Errorf("Answer = %d, want 2048", state.Answer)
+	}
+}
+
+func TestMatchers_ParseInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher Matcher
+		input   string
+	}{
+		{"multiply", NewMultiplyMatcher(), "mul(32,64]"},
+		{"do", NewDoMatcher(), "do()x"},
+		{"don't", NewDontMatcher(), "dont()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.matcher.Parse(tt.input); err == nil {
+				t.Errorf("Parse(%q) error = nil, want error", tt.input)
+			}
+		})
+	}
+}
+
+func TestDoAndDontInstructions(t *testing.T) {
+	state := NewProgramState()
+	state = NewDontInstruction().Execute(state)
+	state = NewMultiplyInstruction(5, 5).Execute(state)
+	if state.Answer != 0 {
+		t.Errorf("Answer after don't() = %d, want 0", state.Answer)
+	}
+	state = NewDoInstruction().Execute(state)
+	state = NewMultiplyInstruction(8, 5).Execute(state)
+	if state.Answer != 40 {
+		t.Errorf("Answer after do() = %d, want 40", state.Answer)
+	}
+}
@@ -15,30 +15,50 @@ import (
 	"advent/day03/interpreter"
 	"advent/util"
 	"bufio"
+	"context"
 )
 
 type Day03Solution struct {
 	filepath string
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   3,
+		Title: "Mull It Over",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay03Solution(filepath)
+		},
+	})
+}
+
 func NewDay03Solution(filepath string) (*Day03Solution, error) {
 	return &Day03Solution{filepath}, nil
 }
 
-func (s *Day03Solution) PartOneAnswer() (int, error) {
+func (s *Day03Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	matchers := []interpreter.Matcher{
 		interpreter.NewMultiplyMatcher(),
 	}
-	return s.runProgramWithMatchers(s.filepath, matchers)
+	answer, err := s.runProgramWithMatchers(s.filepath, matchers)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(answer), nil
 }
 
-func (s *Day03Solution) PartTwoAnswer() (int, error) {
+func (s *Day03Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	matchers := []interpreter.Matcher{
 		interpreter.NewMultiplyMatcher(),
 		interpreter.NewDoMatcher(),
 		interpreter.NewDontMatcher(),
 	}
-	return s.runProgramWithMatchers(s.filepath, matchers)
+	answer, err := s.runProgramWithMatchers(s.filepath, matchers)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(answer), nil
 }
 
 // runProgramWithMatchers runs the interpreter with the given matchers and
@@ -18,6 +18,7 @@ package day04
 
 import (
 	"advent/util"
+	"context"
 	"strings"
 )
 
@@ -27,6 +28,17 @@ type Day04Solution struct {
 	wordSearch util.Matrix[rune]
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   4,
+		Title: "Ceres Search",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay04Solution(filepath)
+		},
+	})
+}
+
 func NewDay04Solution(filepath string) (*Day04Solution, error) {
 	wordSearch, err := util.ParseMatrixFromFile(filepath, func(r rune) rune {
 		return r
@@ -34,12 +46,12 @@ func NewDay04Solution(filepath string) (*Day04Solution, error) {
 	return &Day04Solution{wordSearch}, err
 }
 
-func (s *Day04Solution) PartOneAnswer() (int, error) {
-	return s.countWords(Word, s.wordSearch), nil
+func (s *Day04Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.countWords(Word, s.wordSearch)), nil
 }
 
-func (s *Day04Solution) PartTwoAnswer() (int, error) {
-	return s.countXmases(s.wordSearch), nil
+func (s *Day04Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.countXmases(s.wordSearch)), nil
 }
 
 // countWords returns the number of times the word appears in the matrix.
@@ -120,7 +132,7 @@ func (s *Day04Solution) countXmases(matrix util.Matrix[rune]) int {
 }
 
 // isXmasCenter returns true if the given Vector is the center of an X-MAS.
-func (s *Day04Solution) isXmasCenter(p *util.Vector, matrix util.Matrix[rune]) bool {
+func (s *Day04Solution) isXmasCenter(p util.Vector, matrix util.Matrix[rune]) bool {
 	if matrix.Get(p) != 'A' {
 		return false
 	}
@@ -132,7 +144,7 @@ func (s *Day04Solution) isXmasCenter(p *util.Vector, matrix util.Matrix[rune]) b
 
 // isLeftDiagonalXmasCenter returns true if the given Vector has an M and an S,
 // in either order, at matrix[i-1][j-1] and matrix[i+1][j+1].
-func (s *Day04Solution) isLeftDiagonalXmasCenter(p *util.Vector, matrix util.Matrix[rune]) bool {
+func (s *Day04Solution) isLeftDiagonalXmasCenter(p util.Vector, matrix util.Matrix[rune]) bool {
 	topRight := util.NewVector(p.X-1, p.Y-1)
 	bottomLeft := util.NewVector(p.X+1, p.Y+1)
 	return (matrix.Get(topRight) == 'M' && matrix.Get(bottomLeft) == 'S') ||
@@ -141,7 +153,7 @@ func (s *Day04Solution) isLeftDiagonalXmasCenter(p *util.Vector, matrix util.Mat
 
 // isRightDiagonalXmasCenter returns true if the given Vector has an M and an S,
 // in either order, at matrix[i-1][j+1] and matrix[i+1][j-1].
-func (s *Day04Solution) isRightDiagonalXmasCenter(p *util.Vector, matrix util.Matrix[rune]) bool {
+func (s *Day04Solution) isRightDiagonalXmasCenter(p util.Vector, matrix util.Matrix[rune]) bool {
 	topLeft := util.NewVector(p.X-1, p.Y+1)
 	bottomRight := util.NewVector(p.X+1, p.Y-1)
 	return (matrix.Get(topLeft) == 'M' && matrix.Get(bottomRight) == 'S') ||
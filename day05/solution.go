@@ -18,6 +18,7 @@ package day05
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"slices"
 	"strconv"
 	"strings"
@@ -38,11 +39,22 @@ type Day05Solution struct {
 	filepath string
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   5,
+		Title: "Print Queue",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay05Solution(filepath)
+		},
+	})
+}
+
 func NewDay05Solution(filepath string) (*Day05Solution, error) {
 	return &Day05Solution{filepath: filepath}, nil
 }
 
-func (s *Day05Solution) PartOneAnswer() (int, error) {
+func (s *Day05Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	validOrderingsSum := 0
 	err := util.ProcessFile(s.filepath, func(scanner *bufio.Scanner) error {
 		edges := s.getEdges(scanner)
@@ -55,12 +67,12 @@ func (s *Day05Solution) PartOneAnswer() (int, error) {
 		return err
 	})
 	if err != nil {
-		return 0, err
+		return util.Answer{}, err
 	}
-	return validOrderingsSum, err
+	return util.NewIntAnswer(validOrderingsSum), nil
 }
 
-func (s *Day05Solution) PartTwoAnswer() (int, error) {
+func (s *Day05Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	reorderedSum := 0
 	err := util.ProcessFile(s.filepath, func(scanner *bufio.Scanner) error {
 		edges := s.getEdges(scanner)
@@ -80,9 +92,9 @@ func (s *Day05Solution) PartTwoAnswer() (int, error) {
 		return err
 	})
 	if err != nil {
-		return 0, err
+		return util.Answer{}, err
 	}
-	return reorderedSum, err
+	return util.NewIntAnswer(reorderedSum), nil
 }
 
 // getOrderingsTwo takes a scanner and returns a list of all orderings computed
@@ -20,12 +20,24 @@ package day06
 
 import (
 	"advent/util"
+	"context"
 	"fmt"
 )
 
 type Day06Solution struct {
 	initialLabMap      util.Matrix[rune]
-	initialGuardVector *util.Vector
+	initialGuardVector util.Vector
+}
+
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   6,
+		Title: "Guard Gallivant",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay06Solution(filepath)
+		},
+	})
 }
 
 func NewDay06Solution(filepath string) (*Day06Solution, error) {
@@ -33,32 +45,38 @@ func NewDay06Solution(filepath string) (*Day06Solution, error) {
 	return &Day06Solution{initialLabMap: labMap, initialGuardVector: guard}, err
 }
 
-func (s *Day06Solution) PartOneAnswer() (int, error) {
+func (s *Day06Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	labMap := s.getMatrixCopy(s.initialLabMap)
-	seenVectors, err := s.trackGuard(labMap, s.initialGuardVector)
-	return len(seenVectors), err
+	seenVectors, err := s.trackGuard(ctx, labMap, s.initialGuardVector)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(seenVectors.Len()), nil
 }
 
-func (s *Day06Solution) PartTwoAnswer() (int, error) {
+func (s *Day06Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	labMap := s.getMatrixCopy(s.initialLabMap)
-	seenVectors, err := s.trackGuard(labMap, s.initialGuardVector)
+	seenVectors, err := s.trackGuard(ctx, labMap, s.initialGuardVector)
 	if err != nil {
-		return 0, err
+		return util.Answer{}, err
 	}
 	labMap = s.getMatrixCopy(s.initialLabMap)
-	steps, err := s.countLoops(labMap, s.initialGuardVector, seenVectors)
-	return steps, err
+	steps, err := s.countLoops(ctx, labMap, s.initialGuardVector, seenVectors)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(steps), nil
 }
 
 // getLabMapAndGuard returns the lab map and the guard's Vector in the map,
 // or an error if the file cannot be processed. The file is in the format
 // described in the prompt.
-func getLabMapAndGuard(filename string) (util.Matrix[rune], *util.Vector, error) {
+func getLabMapAndGuard(filename string) (util.Matrix[rune], util.Vector, error) {
 	labMap, err := util.ParseMatrixFromFile(filename, func(r rune) rune {
 		return r
 	})
 	if err != nil {
-		return labMap, &util.Vector{}, err
+		return labMap, util.Vector{}, err
 	}
 	for i, X := range labMap {
 		for j, r := range X {
@@ -67,18 +85,19 @@ func getLabMapAndGuard(filename string) (util.Matrix[rune], *util.Vector, error)
 			}
 		}
 	}
-	return labMap, &util.Vector{}, fmt.Errorf("no guard found in lab map")
+	return labMap, util.Vector{}, fmt.Errorf("no guard found in lab map")
 }
 
 // trackGuard returns all known locations the guard visits on their path. It is not
 // guaranteed that labMap will be unchanged by this function.
-func (s *Day06Solution) trackGuard(labMap util.Matrix[rune], guardPos *util.Vector) (map[*util.Vector]bool, error) {
+func (s *Day06Solution) trackGuard(ctx context.Context, labMap util.Matrix[rune], guardPos util.Vector) (util.Set[util.Vector], error) {
 	var err error
-	seenVectors := make(map[*util.Vector]bool)
+	seenVectors := util.NewSet[util.Vector]()
 	for labMap.PosInBounds(guardPos) {
-		if _, ok := seenVectors[guardPos]; !ok {
-			seenVectors[guardPos] = true
+		if err := util.CheckContext(ctx); err != nil {
+			return seenVectors, err
 		}
+		seenVectors.Add(guardPos)
 		guardPos, err = s.moveToNextVector(labMap, guardPos)
 		if err != nil {
 			return seenVectors, err
@@ -90,13 +109,13 @@ func (s *Day06Solution) trackGuard(labMap util.Matrix[rune], guardPos *util.Vect
 // countLoops returns the number of obstacles that would cause the guard to loop. It needs the lab map,
 // the starting Vector of the guard, and all Vectors the guard is seen at on her original path.
 // It is not guaranteed that labMap will be unchanged by this function.
-func (s *Day06Solution) countLoops(labMap util.Matrix[rune], guardPos *util.Vector, seenVectors map[*util.Vector]bool) (int, error) {
-	delete(seenVectors, guardPos)
+func (s *Day06Solution) countLoops(ctx context.Context, labMap util.Matrix[rune], guardPos util.Vector, seenVectors util.Set[util.Vector]) (int, error) {
+	seenVectors.Remove(guardPos)
 	guard := labMap.Get(guardPos)
 	obstacleVectorCount := 0
-	for pos := range seenVectors {
+	for pos := range seenVectors.All() {
 		labMap.Set(pos, Obstacle)
-		looping, err := s.isLooping(labMap, guardPos)
+		looping, err := s.isLooping(ctx, labMap, guardPos)
 		if err != nil {
 			return 0, err
 		}
@@ -110,10 +129,13 @@ func (s *Day06Solution) countLoops(labMap util.Matrix[rune], guardPos *util.Vect
 }
 
 // isLooping returns true if the guard is looping in the labMap, false otherwise. An error is returned
-// if there is a problem moving the guard.
-func (s *Day06Solution) isLooping(labMap util.Matrix[rune], guardPos *util.Vector) (bool, error) {
-	seenTurns := make(map[*util.Vector]*util.Vector)
+// if there is a problem moving the guard, or if ctx is done.
+func (s *Day06Solution) isLooping(ctx context.Context, labMap util.Matrix[rune], guardPos util.Vector) (bool, error) {
+	seenTurns := make(map[util.Vector]util.Vector)
 	for labMap.PosInBounds(guardPos) {
+		if err := util.CheckContext(ctx); err != nil {
+			return false, err
+		}
 		obstacleDirection, ok := seenTurns[guardPos]
 		currentDirection, err := s.getGuardDirection(labMap.Get(guardPos))
 		if err != nil {
@@ -136,7 +158,7 @@ func (s *Day06Solution) isLooping(labMap util.Matrix[rune], guardPos *util.Vecto
 // moveToNextVector moves the guard to the next Vector in the labMap. If the
 // guard encounters an obstacle, she turns right and steps one forward. Otherwise,
 // she steps one forward in the direction she is currently moving.
-func (s *Day06Solution) moveToNextVector(labMap util.Matrix[rune], guardPos *util.Vector) (*util.Vector, error) {
+func (s *Day06Solution) moveToNextVector(labMap util.Matrix[rune], guardPos util.Vector) (util.Vector, error) {
 	guard := labMap.Get(guardPos)
 	nextVector, err := s.getNextVector(guardPos, guard)
 	if err != nil {
@@ -159,7 +181,7 @@ func (s *Day06Solution) moveToNextVector(labMap util.Matrix[rune], guardPos *uti
 }
 
 // isInFrontOfObstacle returns true if the guard is in front of an obstacle in the labMap.
-func (s *Day06Solution) isInFrontOfObstacle(labMap util.Matrix[rune], guardPos *util.Vector) bool {
+func (s *Day06Solution) isInFrontOfObstacle(labMap util.Matrix[rune], guardPos util.Vector) bool {
 	guard := labMap.Get(guardPos)
 	nextPos, err := s.getNextVector(guardPos, guard)
 	if err != nil {
@@ -181,7 +203,7 @@ func isGuard(r rune) bool {
 
 // getGuardDirection returns the direction of the guard represented by r, or an
 // error if r is not a guard.
-func (s *Day06Solution) getGuardDirection(r rune) (*util.Vector, error) {
+func (s *Day06Solution) getGuardDirection(r rune) (util.Vector, error) {
 	vector, ok := guardDirections[r]
 	if !ok {
 		return util.NewVector(0, 0), fmt.Errorf("rune %c is not a guard", r)
@@ -189,7 +211,7 @@ func (s *Day06Solution) getGuardDirection(r rune) (*util.Vector, error) {
 	return vector, nil
 }
 
-func (s *Day06Solution) getNextVector(currentPos *util.Vector, guard rune) (*util.Vector, error) {
+func (s *Day06Solution) getNextVector(currentPos util.Vector, guard rune) (util.Vector, error) {
 	d, err := s.getGuardDirection(guard)
 	return currentPos.Add(d), err
 }
@@ -15,6 +15,7 @@ package day07
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"strconv"
 	"strings"
 )
@@ -54,19 +55,30 @@ type Day07Solution struct {
 	equations []Equation
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   7,
+		Title: "Bridge Repair",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay07Solution(filepath)
+		},
+	})
+}
+
 func NewDay07Solution(filepath string) (*Day07Solution, error) {
 	equations, err := getEquations(filepath)
 	return &Day07Solution{equations}, err
 }
 
-func (s *Day07Solution) PartOneAnswer() (int, error) {
+func (s *Day07Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	validEquations := s.validEquations([]Operator{Add{}, Multiply{}})
-	return s.leftSideSum(validEquations), nil
+	return util.NewIntAnswer(s.leftSideSum(validEquations)), nil
 }
 
-func (s *Day07Solution) PartTwoAnswer() (int, error) {
+func (s *Day07Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	validEquations := s.validEquations([]Operator{Add{}, Multiply{}, Concatenate{}})
-	return s.leftSideSum(validEquations), nil
+	return util.NewIntAnswer(s.leftSideSum(validEquations)), nil
 }
 
 // leftSideSum returns the sum of the left side of the equations.
@@ -13,12 +13,13 @@ package day08
 
 import (
 	"advent/util"
+	"context"
 )
 
 const Empty = '.'
 
 type Antenna struct {
-	Vector *util.Vector
+	Vector util.Vector
 	symbol rune
 }
 
@@ -27,6 +28,17 @@ type Day08Solution struct {
 	antennas map[rune][]Antenna
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   8,
+		Title: "Resonant Collinearity",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay08Solution(filepath)
+		},
+	})
+}
+
 func NewDay08Solution(filepath string) (*Day08Solution, error) {
 	cityMap, err := util.ParseMatrixFromFile(filepath, func(r rune) rune {
 		return r
@@ -38,14 +50,14 @@ func NewDay08Solution(filepath string) (*Day08Solution, error) {
 	return &Day08Solution{cityMap, antennas}, nil
 }
 
-func (s *Day08Solution) PartOneAnswer() (int, error) {
+func (s *Day08Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	antinodes := s.getAntinodesVectors(s.cityMap, s.antennas, s.getFixedAntinodes)
-	return len(antinodes), nil
+	return util.NewIntAnswer(antinodes.Len()), nil
 }
 
-func (s *Day08Solution) PartTwoAnswer() (int, error) {
+func (s *Day08Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	antinodes := s.getAntinodesVectors(s.cityMap, s.antennas, s.getResonantAntinodes)
-	return len(antinodes), nil
+	return util.NewIntAnswer(antinodes.Len()), nil
 }
 
 // getAntennas returns a map of antennas by their symbol.
@@ -64,8 +76,8 @@ func getAntennas(cityMap util.Matrix[rune]) map[rune][]Antenna {
 // getAntinodesVectors returns all antinodes defined by the list of antennas. It uses getAntinodesFromAntennas
 // to calculate the antinodes for each pair of antennas.
 func (s *Day08Solution) getAntinodesVectors(cityMap util.Matrix[rune], antennasBySymbol map[rune][]Antenna,
-	getAntinodesFromAntennas func(Antenna, Antenna, util.Matrix[rune]) []*util.Vector) map[*util.Vector]bool {
-	antinodes := make(map[*util.Vector]bool)
+	getAntinodesFromAntennas func(Antenna, Antenna, util.Matrix[rune]) []util.Vector) util.Set[util.Vector] {
+	antinodes := util.NewSet[util.Vector]()
 	for _, antennas := range antennasBySymbol {
 		for i, antenna1 := range antennas {
 			for j := i + 1; j < len(antennas); j++ {
@@ -73,7 +85,7 @@ func (s *Day08Solution) getAntinodesVectors(cityMap util.Matrix[rune], antennasB
 				antinodesFromAntennas := getAntinodesFromAntennas(antenna1, antenna2, cityMap)
 				for _, antinode := range antinodesFromAntennas {
 					if cityMap.PosInBounds(antinode) {
-						antinodes[antinode] = true
+						antinodes.Add(antinode)
 					}
 				}
 			}
@@ -85,9 +97,9 @@ func (s *Day08Solution) getAntinodesVectors(cityMap util.Matrix[rune], antennasB
 // getFixedAntinodes returns all fixed antinodes defined by two antennas.
 // As defined by the problem, fixed antinodes can be in two Vectors: the two
 // Vectors that are exactly twice as far from one antenna as the other.
-func (s *Day08Solution) getFixedAntinodes(one, two Antenna, cityMap util.Matrix[rune]) []*util.Vector {
-	fixedAntinodes := make([]*util.Vector, 0)
-	manhattanDistance := two.Vector.GetManhattanDistance(one.Vector)
+func (s *Day08Solution) getFixedAntinodes(one, two Antenna, cityMap util.Matrix[rune]) []util.Vector {
+	fixedAntinodes := make([]util.Vector, 0)
+	manhattanDistance := two.Vector.Sub(one.Vector)
 	negativeManhattanDistance := manhattanDistance.Negate()
 	oneAntinode := one.Vector.Add(negativeManhattanDistance)
 	twoAntinode := two.Vector.Add(manhattanDistance)
@@ -103,9 +115,9 @@ func (s *Day08Solution) getFixedAntinodes(one, two Antenna, cityMap util.Matrix[
 // getResonantAntinodes returns all resonant antinodes defined by two antennas.
 // As defined by the problem, resonant antiodes are any antinodes exactly in
 // line with the two antennas.
-func (s *Day08Solution) getResonantAntinodes(one, two Antenna, cityMap util.Matrix[rune]) []*util.Vector {
-	resonantAntinodes := make([]*util.Vector, 0)
-	manhattanDistance := two.Vector.GetManhattanDistance(one.Vector)
+func (s *Day08Solution) getResonantAntinodes(one, two Antenna, cityMap util.Matrix[rune]) []util.Vector {
+	resonantAntinodes := make([]util.Vector, 0)
+	manhattanDistance := two.Vector.Sub(one.Vector)
 	unitManhattanDistance := manhattanDistance.Unit()
 	negativeUnitManhattanDistance := unitManhattanDistance.Negate()
 	resonantFromOne := one.Vector
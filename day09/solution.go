@@ -22,6 +22,7 @@ package day09
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"slices"
 )
 
@@ -37,19 +38,30 @@ type Day09Solution struct {
 	diskMap []DiskSpan
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   9,
+		Title: "Disk Fragmenter",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay09Solution(filepath)
+		},
+	})
+}
+
 func NewDay09Solution(filepath string) (*Day09Solution, error) {
 	diskMap := getdiskMap(filepath)
 	return &Day09Solution{diskMap}, nil
 }
 
-func (s *Day09Solution) PartOneAnswer() (int, error) {
+func (s *Day09Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	reindexedDiskMap := s.reindexFiles(s.diskMap, true)
-	return s.getDiskMapChecksum(reindexedDiskMap), nil
+	return util.NewIntAnswer(s.getDiskMapChecksum(reindexedDiskMap)), nil
 }
 
-func (s *Day09Solution) PartTwoAnswer() (int, error) {
+func (s *Day09Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	reindexedDiskMap := s.reindexFiles(s.diskMap, false)
-	return s.getDiskMapChecksum(reindexedDiskMap), nil
+	return util.NewIntAnswer(s.getDiskMapChecksum(reindexedDiskMap)), nil
 }
 
 // reindexFilesFragmented moves blocks around to compact the disk map, starting with
@@ -2,15 +2,18 @@
 //
 // https://adventofcode.com/2024/day/10
 //
-// Part 1 Idea: For each trailhead, recursively follow the trail until a peak
-// is reached. We never walk downhill, so we know we cannot reach loops.
+// Part 1 Idea: For each trailhead, search outward, only ever stepping one
+// higher, and count the peaks reached. We never walk downhill, so we know we
+// cannot reach loops.
 //
-// Part 2 Idea: given our part 1 solution, the only thing we need to change is
-// to count each trailhead to peak pair non-uniquely.
+// Part 2 Idea: every trail from a trailhead to a peak takes exactly nine
+// steps, so every trail is a shortest path, and the search can count them.
 package day10
 
 import (
 	"advent/util"
+	"advent/util/search"
+	"context"
 )
 
 const Trailhead = '0'
@@ -20,6 +23,17 @@ type Day10Solution struct {
 	trailMap util.Matrix[rune]
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   10,
+		Title: "Hoof It",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay10Solution(filepath)
+		},
+	})
+}
+
 func NewDay10Solution(filepath string) (*Day10Solution, error) {
 	trailMap, err := util.ParseMatrixFromFile(filepath, func(r rune) rune {
 		return r
@@ -27,12 +41,12 @@ func NewDay10Solution(filepath string) (*Day10Solution, error) {
 	return &Day10Solution{trailMap}, err
 }
 
-func (s *Day10Solution) PartOneAnswer() (int, error) {
-	return s.countReachablePeaks(s.trailMap, true), nil
+func (s *Day10Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.countReachablePeaks(s.trailMap, true)), nil
 }
 
-func (s *Day10Solution) PartTwoAnswer() (int, error) {
-	return s.countReachablePeaks(s.trailMap, false), nil
+func (s *Day10Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.countReachablePeaks(s.trailMap, false)), nil
 }
 
 // countReachablePeaks counts all peaks reachable from a trailhead. If unique
@@ -54,36 +68,32 @@ func (s *Day10Solution) countReachablePeaks(trailMap util.Matrix[rune], unique b
 // If unique is true, then each trailhead to peak pair is only counted once,
 // no matter how many ways there are to reach it. Otherwise, all trails are
 // counted.
-func (s *Day10Solution) countReachablePeaksFrom(trailMap util.Matrix[rune],
-	p *util.Vector, unique bool) int {
-	reachablePeaks := s.getEndOfTrailsFrom(trailMap, p)
-	if unique {
-		return s.countUnique(reachablePeaks)
-	} else {
-		return len(reachablePeaks)
+func (s *Day10Solution) countReachablePeaksFrom(trailMap util.Matrix[rune], p util.Vector, unique bool) int {
+	trails := search.BFS(p, func(pos util.Vector) []util.Vector {
+		return s.uphillNeighbors(trailMap, pos)
+	})
+	reachablePeaks := 0
+	for peak := range trails.Reached() {
+		if trailMap.Get(peak) != Peak {
+			continue
+		}
+		if unique {
+			reachablePeaks++
+		} else {
+			reachablePeaks += trails.CountPaths(peak)
+		}
 	}
+	return reachablePeaks
 }
 
-// countTrailEndsFrom returns the ending position for every trail reachable from p.
-func (s *Day10Solution) getEndOfTrailsFrom(trailMap util.Matrix[rune], p *util.Vector) []*util.Vector {
+// uphillNeighbors returns the positions next to p that are one higher than p.
+func (s *Day10Solution) uphillNeighbors(trailMap util.Matrix[rune], p util.Vector) []util.Vector {
 	elevation := trailMap.Get(p)
-	if elevation == Peak {
-		return []*util.Vector{p}
-	}
-	reachablePeaks := make([]*util.Vector, 0)
-	for _, dir := range util.SimpleDirections {
-		newPos := p.Add(dir)
+	neighbors := make([]util.Vector, 0, len(util.SimpleDirections))
+	for _, newPos := range p.Neighbors4() {
 		if trailMap.PosInBounds(newPos) && trailMap.Get(newPos) == elevation+1 {
-			reachablePeaks = append(reachablePeaks, s.getEndOfTrailsFrom(trailMap, newPos)...)
+			neighbors = append(neighbors, newPos)
 		}
 	}
-	return reachablePeaks
-}
-
-func (s *Day10Solution) countUnique(positions []*util.Vector) int {
-	unique := make(map[*util.Vector]bool)
-	for _, p := range positions {
-		unique[p] = true
-	}
-	return len(unique)
+	return neighbors
 }
@@ -17,21 +17,36 @@ package day11
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"strconv"
 	"strings"
 )
 
-const PartOneIteration = 25
-const PartTwoIteration = 75
-
 type StoneCache map[int][]int
 
 type Day11Solution struct {
 	// stone number mapped to the count of that stone
 	initialStones map[int]int
+	// how many times the stones blink in each part
+	partOneIterations int
+	partTwoIterations int
+}
+
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   11,
+		Title: "Plutonian Pebbles",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay11Solution(filepath, config.Params["iterations1"], config.Params["iterations2"])
+		},
+		Params: map[string]util.Params{
+			"input": {"iterations1": 25, "iterations2": 75},
+		},
+	})
 }
 
-func NewDay11Solution(filepath string) (*Day11Solution, error) {
+func NewDay11Solution(filepath string, partOneIterations, partTwoIterations int) (*Day11Solution, error) {
 	initialStones := make(map[int]int)
 	err := util.ProcessFile(filepath, func(scan *bufio.Scanner) error {
 		for scan.Scan() {
@@ -47,17 +62,17 @@ func NewDay11Solution(filepath string) (*Day11Solution, error) {
 		}
 		return nil
 	})
-	return &Day11Solution{initialStones}, err
+	return &Day11Solution{initialStones, partOneIterations, partTwoIterations}, err
 }
 
-func (s *Day11Solution) PartOneAnswer() (int, error) {
-	stones := s.applyStandardRulesTimes(s.initialStones, PartOneIteration)
-	return s.totalCounts(stones), nil
+func (s *Day11Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	stones := s.applyStandardRulesTimes(s.initialStones, s.partOneIterations)
+	return util.NewIntAnswer(s.totalCounts(stones)), nil
 }
 
-func (s *Day11Solution) PartTwoAnswer() (int, error) {
-	stones := s.applyStandardRulesTimes(s.initialStones, PartTwoIteration)
-	return s.totalCounts(stones), nil
+func (s *Day11Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	stones := s.applyStandardRulesTimes(s.initialStones, s.partTwoIterations)
+	return util.NewIntAnswer(s.totalCounts(stones)), nil
 }
 
 // applyStandardRulesTimes applies the standard rules to the initial stones
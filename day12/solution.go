@@ -17,7 +17,8 @@ package day12
 
 import (
 	"advent/util"
-	"fmt"
+	"context"
+	"log/slog"
 )
 
 type GardenSquare struct {
@@ -27,23 +28,35 @@ type GardenSquare struct {
 
 type Day12Solution struct {
 	gardenMap util.Matrix[rune]
+	logger    *slog.Logger
 }
 
-func NewDay12Solution(filepath string) (*Day12Solution, error) {
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   12,
+		Title: "Garden Groups",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay12Solution(filepath, config.Logger)
+		},
+	})
+}
+
+func NewDay12Solution(filepath string, logger *slog.Logger) (*Day12Solution, error) {
 	gardenMap, err := util.ParseMatrixFromFile(filepath, func(r rune) rune {
 		return r
 	})
-	return &Day12Solution{gardenMap}, err
+	return &Day12Solution{gardenMap, logger}, err
 }
 
-func (s *Day12Solution) PartOneAnswer() (int, error) {
+func (s *Day12Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	gardenSquareMap := getGardenSquareMap(s.gardenMap)
-	return s.getFencingPrice(gardenSquareMap), nil
+	return util.NewIntAnswer(s.getFencingPrice(gardenSquareMap)), nil
 }
 
-func (s *Day12Solution) PartTwoAnswer() (int, error) {
+func (s *Day12Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	gardenSquareMap := getGardenSquareMap(s.gardenMap)
-	return s.getFencingPriceWithDiscount(gardenSquareMap), nil
+	return util.NewIntAnswer(s.getFencingPriceWithDiscount(gardenSquareMap)), nil
 }
 
 func (s *Day12Solution) getFencingPrice(gardenSquareMap util.Matrix[GardenSquare]) int {
@@ -65,7 +78,7 @@ func (s *Day12Solution) getFencingPriceWithDiscount(gardenSquareMap util.Matrix[
 		for j := range gardenSquareMap[i] {
 			if !gardenSquareMap[i][j].visited {
 				area, corners := s.getFencingAreaAndCorners(gardenSquareMap, util.NewVector(i, j))
-				fmt.Println(string(gardenSquareMap[i][j].Plant), area, corners)
+				s.logger.Debug("found region", "plant", string(gardenSquareMap[i][j].Plant), "area", area, "corners", corners)
 				price += area * corners
 			}
 		}
@@ -78,7 +91,7 @@ func (s *Day12Solution) getFencingPriceWithDiscount(gardenSquareMap util.Matrix[
 // problem, the permiter is the number of sides of the region that are adjacent
 // to the edge of the garden or different garden plots, and the area is the
 // total number of squares in the plot.
-func (s *Day12Solution) getFencingAreaAndPerimiter(gardenMap util.Matrix[GardenSquare], p *util.Vector) (int, int) {
+func (s *Day12Solution) getFencingAreaAndPerimiter(gardenMap util.Matrix[GardenSquare], p util.Vector) (int, int) {
 	currentSquare := gardenMap.Get(p)
 	if currentSquare.visited {
 		return 0, 0
@@ -86,8 +99,7 @@ func (s *Day12Solution) getFencingAreaAndPerimiter(gardenMap util.Matrix[GardenS
 	gardenMap[p.X][p.Y].visited = true
 	area := 1
 	perimiter := 0
-	for _, d := range util.SimpleDirections {
-		newPos := p.Add(d)
+	for _, newPos := range p.Neighbors4() {
 		directionInRegion := gardenMap.PosInBounds(newPos) && gardenMap.Get(newPos).Plant == currentSquare.Plant
 		if directionInRegion {
 			newArea, newPerimiter := s.getFencingAreaAndPerimiter(gardenMap, newPos)
@@ -102,7 +114,7 @@ func (s *Day12Solution) getFencingAreaAndPerimiter(gardenMap util.Matrix[GardenS
 
 // getFencingAreaAndCorners returns the area and number of corners of the
 // remaining part of the region starting at p that is unvisited.
-func (s *Day12Solution) getFencingAreaAndCorners(gardenMap util.Matrix[GardenSquare], p *util.Vector) (int, int) {
+func (s *Day12Solution) getFencingAreaAndCorners(gardenMap util.Matrix[GardenSquare], p util.Vector) (int, int) {
 	currentSquare := gardenMap.Get(p)
 	if currentSquare.visited {
 		return 0, 0
@@ -110,8 +122,7 @@ func (s *Day12Solution) getFencingAreaAndCorners(gardenMap util.Matrix[GardenSqu
 	gardenMap[p.X][p.Y].visited = true
 	area := 1
 	corners := 0
-	for _, d := range util.SimpleDirections {
-		newPos := p.Add(d)
+	for _, newPos := range p.Neighbors4() {
 		directionInRegion := gardenMap.PosInBounds(newPos) && gardenMap.Get(newPos).Plant == currentSquare.Plant
 		if directionInRegion {
 			newArea, newCorners := s.getFencingAreaAndCorners(gardenMap, newPos)
@@ -122,8 +133,7 @@ func (s *Day12Solution) getFencingAreaAndCorners(gardenMap util.Matrix[GardenSqu
 
 	// Check for corners
 	directionsInRegion := make([]bool, len(util.AllDirections))
-	for i, d := range util.AllDirections {
-		newPos := p.Add(d)
+	for i, newPos := range p.Neighbors8() {
 		directionsInRegion[i] = gardenMap.PosInBounds(newPos) && gardenMap.Get(newPos).Plant == currentSquare.Plant
 	}
 	for i := 0; i < len(directionsInRegion); i += 2 {
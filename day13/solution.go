@@ -43,6 +43,7 @@ package day13
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -67,6 +68,17 @@ type Day13Solution struct {
 	equationSystems []*EquationSystem
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   13,
+		Title: "Claw Contraption",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay13Solution(filepath)
+		},
+	})
+}
+
 func NewDay13Solution(filepath string) (*Day13Solution, error) {
 	equationSystems := make([]*EquationSystem, 0)
 	err := util.ProcessFile(filepath, func(scanner *bufio.Scanner) error {
@@ -82,12 +94,12 @@ func NewDay13Solution(filepath string) (*Day13Solution, error) {
 	return &Day13Solution{equationSystems}, err
 }
 
-func (s *Day13Solution) PartOneAnswer() (int, error) {
-	return s.getFewestTokensNeeded(s.equationSystems, false), nil
+func (s *Day13Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.getFewestTokensNeeded(s.equationSystems, false)), nil
 }
 
-func (s *Day13Solution) PartTwoAnswer() (int, error) {
-	return s.getFewestTokensNeeded(s.equationSystems, true), nil
+func (s *Day13Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.getFewestTokensNeeded(s.equationSystems, true)), nil
 }
 
 // getFewestTokensNeeded returns the fewest number of tokens needed to solve the
@@ -15,24 +15,45 @@ package day14
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
 	"strings"
 )
 
-const PartOneSteps = 100
-const HallwayWidth = 101
-const HallwayHeight = 103
-
 type RobotInfo struct {
-	pos *util.Vector
-	vel *util.Vector
+	pos util.Vector
+	vel util.Vector
 }
 
 type Day14Solution struct {
 	robotInfos []*RobotInfo
+	logger     *slog.Logger
+	// width and height are the hallway's dimensions, and partOneSteps how
+	// many steps the robots take in part one.
+	width        int
+	height       int
+	partOneSteps int
 }
 
-func NewDay14Solution(filepath string) (*Day14Solution, error) {
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   14,
+		Title: "Restroom Redoubt",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			params := config.Params
+			return NewDay14Solution(filepath, config.Logger, params["width"], params["height"], params["steps"])
+		},
+		Params: map[string]util.Params{
+			"input": {"width": 101, "height": 103, "steps": 100},
+			"test":  {"width": 11, "height": 7, "steps": 100},
+		},
+	})
+}
+
+func NewDay14Solution(filepath string, logger *slog.Logger, width, height, partOneSteps int) (*Day14Solution, error) {
 	robotInfos := make([]*RobotInfo, 0)
 	err := util.ProcessFile(filepath, func(scanner *bufio.Scanner) error {
 		for scanner.Scan() {
@@ -45,25 +66,29 @@ func NewDay14Solution(filepath string) (*Day14Solution, error) {
 		}
 		return nil
 	})
-	return &Day14Solution{robotInfos}, err
+	return &Day14Solution{robotInfos, logger, width, height, partOneSteps}, err
 }
 
-func (s *Day14Solution) PartOneAnswer() (int, error) {
-	robotInfos := s.stateAfterXSteps(s.robotInfos, PartOneSteps)
-	return s.getSafetyFactor(robotInfos), nil
+func (s *Day14Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	robotInfos := s.stateAfterXSteps(s.robotInfos, s.partOneSteps)
+	return util.NewIntAnswer(s.getSafetyFactor(robotInfos)), nil
 }
 
-func (s *Day14Solution) PartTwoAnswer() (int, error) {
-	return s.christmasTreeSteps(s.robotInfos), nil
+func (s *Day14Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	steps, err := s.christmasTreeSteps(ctx, s.robotInfos)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(steps), nil
 }
 
 // stateAfterXSteps returns the state of the robots after steps steps.
 func (s *Day14Solution) stateAfterXSteps(robotInfos []*RobotInfo, steps int) []*RobotInfo {
-	bounds := util.NewVector(HallwayWidth, HallwayHeight)
+	bounds := util.NewVector(s.width, s.height)
 	newRobotInfos := make([]*RobotInfo, len(robotInfos))
 	for i, robotInfo := range robotInfos {
 		newRobotInfo := &RobotInfo{
-			robotInfo.pos.Add(robotInfo.vel.ScalarMultiply(steps)).MathModulo(bounds),
+			robotInfo.pos.Add(robotInfo.vel.Scale(steps)).MathModulo(bounds),
 			robotInfo.vel,
 		}
 		newRobotInfos[i] = newRobotInfo
@@ -72,16 +97,30 @@ func (s *Day14Solution) stateAfterXSteps(robotInfos []*RobotInfo, steps int) []*
 }
 
 // christmasTreeSteps returns the number of steps it takes for the robots to
-// form a christmas tree.
-func (s *Day14Solution) christmasTreeSteps(robotInfos []*RobotInfo) int {
+// form a christmas tree. The robots are back where they started after
+// width*height steps, so if they haven't formed one by then, they never will,
+// and an error is returned.
+func (s *Day14Solution) christmasTreeSteps(ctx context.Context, robotInfos []*RobotInfo) (int, error) {
 	steps := 0
 	for !s.isChristmasTree(robotInfos) {
+		if err := util.CheckContext(ctx); err != nil {
+			return steps, err
+		}
+		if steps == s.width*s.height {
+			return steps, fmt.Errorf("no christmas tree found in %d steps, after which the robots repeat", steps)
+		}
 		newRobotInfos := s.stateAfterXSteps(robotInfos, 1)
 		steps++
 		robotInfos = newRobotInfos
 	}
-	printState(robotInfos)
-	return steps
+	s.logger.Debug("found christmas tree", "steps", steps)
+	if s.logger.Enabled(ctx, slog.LevelDebug) {
+		// one row at a time, so that the tree can still be seen
+		for _, row := range strings.Split(s.stateString(robotInfos), "\n") {
+			s.logger.Debug("hallway", "row", row)
+		}
+	}
+	return steps, nil
 }
 
 // isChristmasTree returns whether the robots are in the shape of a christmas
@@ -107,9 +146,9 @@ func (s *Day14Solution) getSafetyFactor(robotInfos []*RobotInfo) int {
 // getQuadrant returns the quadrant of a given position. If the position is on
 // the exact middle line vertically or horizontally, it is considered to be in
 // no quadrant and -1 is returned.
-func (s *Day14Solution) getQuadrant(pos *util.Vector) int {
-	equator := (HallwayWidth - 1) / 2
-	meridian := (HallwayHeight - 1) / 2
+func (s *Day14Solution) getQuadrant(pos util.Vector) int {
+	equator := (s.width - 1) / 2
+	meridian := (s.height - 1) / 2
 	if pos.X < equator && pos.Y < meridian {
 		return 0
 	} else if pos.X > equator && pos.Y < meridian {
@@ -136,28 +175,31 @@ func getRobotStartingInfo(line string) (*RobotInfo, error) {
 	return &RobotInfo{pos, vel}, nil
 }
 
-// printState prints the a map with the robots' positions.
-func printState(robotInfos []*RobotInfo) {
+// stateString returns a map with the robots' positions, showing the number of
+// robots in each occupied position.
+func (s *Day14Solution) stateString(robotInfos []*RobotInfo) string {
 	positions := getPositions(robotInfos)
-	for y := 0; y < HallwayHeight; y++ {
-		for x := 0; x < HallwayWidth; x++ {
-			if count, ok := positions[*util.NewVector(x, y)]; ok {
-				fmt.Print(count)
+	var b strings.Builder
+	for y := 0; y < s.height; y++ {
+		for x := 0; x < s.width; x++ {
+			if count, ok := positions[util.NewVector(x, y)]; ok {
+				b.WriteString(strconv.Itoa(count))
 			} else {
-				fmt.Print(".")
+				b.WriteString(".")
 			}
 		}
-		fmt.Println()
+		b.WriteString("\n")
 	}
+	return b.String()
 }
 
 // hasLineOfSize returns whether the robots form a line of size size.
 func (s *Day14Solution) hasLineOfSize(robotInfos []*RobotInfo, size int) bool {
 	positions := getPositions(robotInfos)
-	for y := 0; y < HallwayHeight; y++ {
+	for y := 0; y < s.height; y++ {
 		lineSize := 0
-		for x := 0; x < HallwayWidth; x++ {
-			if _, ok := positions[*util.NewVector(x, y)]; ok {
+		for x := 0; x < s.width; x++ {
+			if _, ok := positions[util.NewVector(x, y)]; ok {
 				lineSize++
 				if lineSize >= size {
 					return true
@@ -175,10 +217,10 @@ func (s *Day14Solution) hasLineOfSize(robotInfos []*RobotInfo, size int) bool {
 func getPositions(robotInfos []*RobotInfo) map[util.Vector]int {
 	positions := make(map[util.Vector]int)
 	for _, robotInfo := range robotInfos {
-		if _, ok := positions[*robotInfo.pos]; !ok {
-			positions[*robotInfo.pos] = 0
+		if _, ok := positions[robotInfo.pos]; !ok {
+			positions[robotInfo.pos] = 0
 		}
-		positions[*robotInfo.pos]++
+		positions[robotInfo.pos]++
 	}
 	return positions
 }
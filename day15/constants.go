@@ -4,7 +4,7 @@ import "advent/util"
 
 const RobotRune = '@'
 
-var RuneDirections = map[rune]*util.Vector{
+var RuneDirections = map[rune]util.Vector{
 	'^': util.UpDirection,
 	'v': util.DownDirection,
 	'>': util.RightDirection,
@@ -13,6 +13,7 @@ package day15
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
 	"slices"
 )
@@ -20,7 +21,18 @@ import (
 type Day15Solution struct {
 	storageMap    util.Matrix[rune]
 	instructions  []rune
-	robotPosition *util.Vector
+	robotPosition util.Vector
+}
+
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   15,
+		Title: "Warehouse Woes",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay15Solution(filepath)
+		},
+	})
 }
 
 func NewDay15Solution(filename string) (*Day15Solution, error) {
@@ -42,33 +54,36 @@ func NewDay15Solution(filename string) (*Day15Solution, error) {
 		}
 		return nil
 	})
-	robotPosition := findRobot(storageMap)
+	robotPosition, ok := findRobot(storageMap)
+	if err == nil && !ok {
+		err = fmt.Errorf("no robot found in the storage map")
+	}
 	return &Day15Solution{storageMap, instructions, robotPosition}, err
 }
 
-func (s *Day15Solution) PartOneAnswer() (int, error) {
+func (s *Day15Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	storageMap := s.storageMap.Copy()
 	err := s.makeMoves(storageMap, s.robotPosition, s.instructions)
 	if err != nil {
-		return 0, err
+		return util.Answer{}, err
 	}
 	gpsCoordinates := s.getGpsCoordinates(storageMap)
-	return util.SliceSum(gpsCoordinates), nil
+	return util.NewIntAnswer(util.SliceSum(gpsCoordinates)), nil
 }
 
-func (s *Day15Solution) PartTwoAnswer() (int, error) {
+func (s *Day15Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	widerMap, robotPos := s.widenMap(s.storageMap)
 	err := s.makeMoves(widerMap, robotPos, s.instructions)
 	if err != nil {
-		return 0, err
+		return util.Answer{}, err
 	}
 	gpsCoordinates := s.getGpsCoordinates(widerMap)
-	return util.SliceSum(gpsCoordinates), nil
+	return util.NewIntAnswer(util.SliceSum(gpsCoordinates)), nil
 }
 
 // makeMoves will make the moves specified by the moves slice, in order. It
 // returns an error if there is no robot at robotPos.
-func (s *Day15Solution) makeMoves(storageMap util.Matrix[rune], robotPos *util.Vector, moves []rune) error {
+func (s *Day15Solution) makeMoves(storageMap util.Matrix[rune], robotPos util.Vector, moves []rune) error {
 	var err error
 	for _, move := range moves {
 		robotPos, err = s.makeMove(storageMap, robotPos, move)
@@ -82,7 +97,7 @@ func (s *Day15Solution) makeMoves(storageMap util.Matrix[rune], robotPos *util.V
 // makeMove will move the robot at robotPos, returning an error if there is no
 // robot at robotPos. The move made is a move on storageMap specified by the
 // problem statement. storageMap is modified by the next move.
-func (s *Day15Solution) makeMove(storageMap util.Matrix[rune], robotPos *util.Vector, move rune) (*util.Vector, error) {
+func (s *Day15Solution) makeMove(storageMap util.Matrix[rune], robotPos util.Vector, move rune) (util.Vector, error) {
 	if storageMap.Get(robotPos) != RobotRune {
 		return robotPos, fmt.Errorf("no robot at position %v", robotPos)
 	}
@@ -103,7 +118,7 @@ func (s *Day15Solution) makeMove(storageMap util.Matrix[rune], robotPos *util.Ve
 }
 
 // getMoveFunction returns the appropriate function for the rune r
-func (s *Day15Solution) getMoveFunction(r rune) (func(util.Matrix[rune], *util.Vector, *util.Vector) bool, error) {
+func (s *Day15Solution) getMoveFunction(r rune) (func(util.Matrix[rune], util.Vector, util.Vector) bool, error) {
 	switch r {
 	case EmptyRune:
 		return s.makeEmptyMove, nil
@@ -123,7 +138,7 @@ func (s *Day15Solution) getMoveFunction(r rune) (func(util.Matrix[rune], *util.V
 // made. It assumes obPos is a moveable object. Otherwise, behavior is
 // unspecified. If obPos is part of a wide box, it is assumed the move can be
 // made. Otherwise, behavior is unspecified.
-func (s *Day15Solution) makeBoxMove(storageMap util.Matrix[rune], objPos, dir *util.Vector) bool {
+func (s *Day15Solution) makeBoxMove(storageMap util.Matrix[rune], objPos, dir util.Vector) bool {
 	nextPos := objPos.Add(dir)
 	nextObj := storageMap.Get(nextPos)
 	switch nextObj {
@@ -152,7 +167,7 @@ func (s *Day15Solution) makeBoxMove(storageMap util.Matrix[rune], objPos, dir *u
 // - storageMap[pos] is empty
 // - storageMap[pos] is a box and can be moved in the direction dir
 // - storageMap[pos] is a wide box and can be moved in the direction dir
-func (s *Day15Solution) canBeMovedTo(storageMap util.Matrix[rune], pos, dir *util.Vector) bool {
+func (s *Day15Solution) canBeMovedTo(storageMap util.Matrix[rune], pos, dir util.Vector) bool {
 	obj := storageMap.Get(pos)
 	switch obj {
 	case EmptyRune:
@@ -173,7 +188,7 @@ func (s *Day15Solution) canBeMovedTo(storageMap util.Matrix[rune], pos, dir *uti
 
 // makeEmptyMove moves obPos to nextPos, leaving obPos empty. It assumes obPos
 // is moveable and nextPos is empty, otherwise behavior is unspecified.
-func (s *Day15Solution) makeEmptyMove(storageMap util.Matrix[rune], obPos, dir *util.Vector) bool {
+func (s *Day15Solution) makeEmptyMove(storageMap util.Matrix[rune], obPos, dir util.Vector) bool {
 	nextPos := obPos.Add(dir)
 	ob := storageMap.Get(obPos)
 	storageMap.Set(obPos, EmptyRune)
@@ -183,7 +198,7 @@ func (s *Day15Solution) makeEmptyMove(storageMap util.Matrix[rune], obPos, dir *
 
 // makeWallMove does nothing, as happens when the robot is facing a wall. It
 // returns false.
-func (s *Day15Solution) makeWallMove(storageMap util.Matrix[rune], obPos, dir *util.Vector) bool {
+func (s *Day15Solution) makeWallMove(storageMap util.Matrix[rune], obPos, dir util.Vector) bool {
 	return false
 }
 
@@ -209,9 +224,9 @@ func (s *Day15Solution) getGpsCoordinates(storageMap util.Matrix[rune]) []int {
 //	'@' -> '@.'
 //
 // It returns the map, and the new position of the robot.
-func (s *Day15Solution) widenMap(storageMap util.Matrix[rune]) (util.Matrix[rune], *util.Vector) {
+func (s *Day15Solution) widenMap(storageMap util.Matrix[rune]) (util.Matrix[rune], util.Vector) {
 	widerMap := util.NewMatrix[rune]()
-	var robotPosition *util.Vector
+	var robotPosition util.Vector
 	for i, row := range storageMap {
 		widerRow := make([]rune, len(row)*2)
 		for j, cell := range row {
@@ -234,27 +249,27 @@ func (s *Day15Solution) widenMap(storageMap util.Matrix[rune]) (util.Matrix[rune
 }
 
 // wideBoxOtherSideDireciton returns the direction to the other side of a wide
-// box, or nil if r is not part of a wide box.
-func (s *Day15Solution) wideBoxOtherSideDirection(r rune) *util.Vector {
+// box, or the zero vector if r is not part of a wide box.
+func (s *Day15Solution) wideBoxOtherSideDirection(r rune) util.Vector {
 	switch r {
 	case WideBoxLeftRune:
 		return util.RightDirection
 	case WideBoxRightRune:
 		return util.LeftDirection
 	default:
-		return nil
+		return util.Vector{}
 	}
 }
 
 // findRobot finds the robot designated by RobotRune in storageMap, and returns
-// the position (or nil if not found)
-func findRobot(storageMap util.Matrix[rune]) *util.Vector {
+// the position, and whether it was found
+func findRobot(storageMap util.Matrix[rune]) (util.Vector, bool) {
 	for i, row := range storageMap {
 		for j, cell := range row {
 			if cell == RobotRune {
-				return util.NewVector(i, j)
+				return util.NewVector(i, j), true
 			}
 		}
 	}
-	return nil
+	return util.Vector{}, false
 }
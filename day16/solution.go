@@ -2,21 +2,24 @@
 //
 // https://adventofcode.com/2024/day/16
 //
-// Part 1: I decided to start with a simple recursive search, that solved the
-// test cases but was not preformant. I then moved on to optimize the solution.
-// Namely, if we keep track of the least cost we've found to get to the end, we
-// can save ourselves the trouble of trying a path we already know to be more
-// expensive. This by definition will truncate the long running paths, and
-// greatly improve preformance time. With this optimization, I was able to find
-// the answer.
+// Part 1: I started with a simple recursive search, that solved the test cases
+// but was not performant. The maze is really a graph whose nodes are a
+// position and a facing, where stepping forward costs 1 and turning costs
+// 1000, so Dijkstra's algorithm finds the least cost to each node. The answer
+// is the least cost of reaching the end, facing any way.
 //
-// Part 2: I decided to make a solution data structure, to store the solution.
-// Though not very fast, this does work and is readable.
+// Part 2: the search records every predecessor of a node on a least cost path
+// to it, so walking back from the cheapest ends finds every node on any of the
+// least cost paths. I store both answers in a solution data structure, so the
+// search only runs once, in Prepare, where it is timed as a phase of its own.
 package day16
 
 import (
 	"advent/util"
+	"advent/util/search"
+	"context"
 	"fmt"
+	"sync"
 )
 
 const MoveCost = 1
@@ -24,203 +27,160 @@ const TurnCost = 1000
 
 const WallRune = '#'
 
-type CellInfo struct {
-	sym        rune
-	foundCosts map[util.Vector]int
+// reindeer is where the reindeer is in the maze, and which way it is facing.
+type reindeer struct {
+	pos, dir util.Vector
 }
 
 type SolutionData struct {
 	leastCost   int
-	cellsOnPath map[util.Vector]bool
+	cellsOnPath util.Set[util.Vector]
 }
 
 type Day16Solution struct {
-	maze         util.Matrix[rune]
-	start, end   *util.Vector
+	maze       util.Matrix[rune]
+	start, end util.Vector
+	// solutionData is filled in by the first search to succeed, and guarded by
+	// mu so that both parts can run at once.
+	mu           sync.Mutex
 	solutionData *SolutionData
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   16,
+		Title: "Reindeer Maze",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay16Solution(filepath)
+		},
+	})
+}
+
 func NewDay16Solution(filename string) (*Day16Solution, error) {
 	maze, err := util.ParseMatrixFromFile(filename, func(r rune) rune {
 		return r
 	})
 	start, end := getStartAndEnd(maze)
-	return &Day16Solution{maze, start, end, nil}, err
+	return &Day16Solution{maze: maze, start: start, end: end}, err
 }
 
-func (s *Day16Solution) PartOneAnswer() (int, error) {
-	return s.findLeastCost()
+func (s *Day16Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	leastCost, err := s.findLeastCost(ctx)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(leastCost), nil
 }
 
-func (s *Day16Solution) PartTwoAnswer() (int, error) {
-	return s.findCellCount()
+func (s *Day16Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	cellCount, err := s.findCellCount(ctx)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(cellCount), nil
+}
+
+// Prepare searches the maze for both parts.
+func (s *Day16Solution) Prepare(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.solveIfNeeded(ctx)
 }
 
 // findLeastCost returns the least cost to reach the end cell from the start.
-func (s *Day16Solution) findLeastCost() (int, error) {
-	if s.solutionData == nil {
-		err := s.solve()
-		if err != nil {
-			return -1, err
-		}
+func (s *Day16Solution) findLeastCost(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.solveIfNeeded(ctx); err != nil {
+		return -1, err
 	}
 	return s.solutionData.leastCost, nil
 }
 
 // findCellCount returns the number of cells found on any of the least cost paths.
-func (s *Day16Solution) findCellCount() (int, error) {
-	if s.solutionData == nil {
-		err := s.solve()
-		if err != nil {
-			return -1, err
-		}
+func (s *Day16Solution) findCellCount(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.solveIfNeeded(ctx); err != nil {
+		return -1, err
 	}
 	return s.cellsOnPath()
 }
 
-func (s *Day16Solution) solve() error {
-	mazeInfo := s.getMazeInfoMap(s.maze)
-	return s.findLeastCostHelper(mazeInfo, s.start, s.end, util.RightDirection, make(map[util.Vector]bool), 0, []string{})
+// solveIfNeeded searches the maze with ctx, unless an earlier search has
+// already succeeded. A failed search is not kept, so the next caller searches
+// again with its own ctx. s.mu must be held.
+func (s *Day16Solution) solveIfNeeded(ctx context.Context) error {
+	if s.solutionData != nil {
+		return nil
+	}
+	return s.solve(ctx)
 }
 
-// findLeastCostHelper fills in s.solutionData with the least cost to reach each
-// cell, facing each direction. It returns an error if the search fails.
-func (s *Day16Solution) findLeastCostHelper(mazeSearch util.Matrix[CellInfo], start, end, dir *util.Vector, visited map[util.Vector]bool, curCost int, moves []string) error {
-	foundCosts := mazeSearch.Get(start).foundCosts
-	if start.Equals(end) {
-		visited[*start] = true
-		if s.solutionData == nil || curCost < s.solutionData.leastCost {
-			s.solutionData = &SolutionData{curCost, s.trueSet(visited)}
-		} else if curCost == s.solutionData.leastCost {
-			s.solutionData.cellsOnPath = s.combinedTrueSets(s.solutionData.cellsOnPath, visited)
+// solve fills in s.solutionData. If the search is stopped early, solutionData
+// is left unset, so that a partial result is never mistaken for the answer.
+func (s *Day16Solution) solve(ctx context.Context) error {
+	start := reindeer{s.start, util.RightDirection}
+	paths := search.Dijkstra(start, func(r reindeer) []search.Edge[reindeer] {
+		// once ctx is done, the search is cut short by finding no more moves
+		if util.CheckContext(ctx) != nil {
+			return nil
 		}
-		return nil
-	} else if foundCost, ok := foundCosts[*dir]; ok && curCost > foundCost {
-		return nil
-	} else if visited[*start] {
-		return nil
+		return s.moves(r)
+	})
+	if err := util.CheckContext(ctx); err != nil {
+		return err
 	}
-	mazeSearch.Get(start).foundCosts[*dir] = curCost
-	// let's first try moving forward
-	newPos := start.Add(dir)
-	if mazeSearch.Get(newPos).sym != WallRune {
-		visited[*start] = true
-		err := s.findLeastCostHelper(mazeSearch, newPos, end, dir, visited, curCost+MoveCost, append(moves, "move"))
-		if err != nil {
-			return err
+
+	leastCost := -1
+	for _, dir := range util.SimpleDirections {
+		if cost, ok := paths.Cost(reindeer{s.end, dir}); ok && (leastCost < 0 || cost < leastCost) {
+			leastCost = cost
 		}
-		visited[*start] = false
-	}
-	// and then try turning
-	leftTurn, err := s.getLeftTurn(dir)
-	if err != nil {
-		return err
 	}
-	err = s.findLeastCostHelper(mazeSearch, start, end, leftTurn, visited, curCost+TurnCost, append(moves, "left"))
-	if err != nil {
-		return err
+	if leastCost < 0 {
+		return fmt.Errorf("no path from %v to %v", s.start, s.end)
 	}
-	rightTurn, err := s.getRightTurn(dir)
-	if err != nil {
-		return err
+	var ends []reindeer
+	for _, dir := range util.SimpleDirections {
+		end := reindeer{s.end, dir}
+		if cost, ok := paths.Cost(end); ok && cost == leastCost {
+			ends = append(ends, end)
+		}
 	}
-	err = s.findLeastCostHelper(mazeSearch, start, end, rightTurn, visited, curCost+TurnCost, append(moves, "right"))
-	if err != nil {
-		return err
+	cellsOnPath := util.NewSet[util.Vector]()
+	for r := range paths.OnOptimalPaths(ends...).All() {
+		cellsOnPath.Add(r.pos)
 	}
+	s.solutionData = &SolutionData{leastCost, cellsOnPath}
 	return nil
 }
 
-// getLeftTurn returns a new direction that is the result of turning left from
-// the current direction. An error is returned if the current direction is not
-// a simple direction.
-func (s *Day16Solution) getLeftTurn(dir *util.Vector) (*util.Vector, error) {
-	switch dir {
-	case util.UpDirection:
-		return util.LeftDirection, nil
-	case util.DownDirection:
-		return util.RightDirection, nil
-	case util.LeftDirection:
-		return util.DownDirection, nil
-	case util.RightDirection:
-		return util.UpDirection, nil
-	default:
-		return nil, fmt.Errorf("invalid direction: %v", dir)
-	}
-}
-
-// getRightTurn returns a new direction that is the result of turning right from
-// the current direction. An error is returned if the current direction is not
-// a simple direction.
-func (s *Day16Solution) getRightTurn(dir *util.Vector) (*util.Vector, error) {
-	switch dir {
-	case util.UpDirection:
-		return util.RightDirection, nil
-	case util.DownDirection:
-		return util.LeftDirection, nil
-	case util.LeftDirection:
-		return util.UpDirection, nil
-	case util.RightDirection:
-		return util.DownDirection, nil
-	default:
-		return nil, fmt.Errorf("invalid direction: %v", dir)
+// moves returns what the reindeer can do next: step forward, unless there is
+// a wall in the way, or turn left or right.
+func (s *Day16Solution) moves(r reindeer) []search.Edge[reindeer] {
+	moves := make([]search.Edge[reindeer], 0, 3)
+	forward := r.pos.Add(r.dir)
+	if s.maze.Get(forward) != WallRune {
+		moves = append(moves, search.Edge[reindeer]{To: reindeer{forward, r.dir}, Cost: MoveCost})
 	}
+	return append(moves,
+		search.Edge[reindeer]{To: reindeer{r.pos, r.dir.RotateCCW()}, Cost: TurnCost},
+		search.Edge[reindeer]{To: reindeer{r.pos, r.dir.RotateCW()}, Cost: TurnCost},
+	)
 }
 
 func (s *Day16Solution) cellsOnPath() (int, error) {
 	if s.solutionData == nil {
 		return -1, fmt.Errorf("solution data not found")
 	}
-	trueCount := 0
-	for _, onPath := range s.solutionData.cellsOnPath {
-		if onPath {
-			trueCount++
-		}
-	}
-	return trueCount, nil
-}
-
-// copiedSet returns a copy of the given set.
-func (s *Day16Solution) trueSet(set map[util.Vector]bool) map[util.Vector]bool {
-	copied := make(map[util.Vector]bool)
-	for k, v := range set {
-		if v {
-			copied[k] = v
-		}
-	}
-	return copied
-}
-
-// combinedSets takes two sets, and returns the combination of the two.
-func (s *Day16Solution) combinedTrueSets(set1, set2 map[util.Vector]bool) map[util.Vector]bool {
-	combined := make(map[util.Vector]bool)
-	for k, v := range s.trueSet(set1) {
-		combined[k] = v
-	}
-	for k, v := range s.trueSet(set2) {
-		combined[k] = v
-	}
-	return combined
-}
-
-// getMazeInfoMap returns a matrix of CellInfo structs, one for each cell in
-// the maze. The CellInfo struct contains the rune of the cell, and a map of
-// directions to the least cost found to reach the end from that cell facing
-// that direction.
-func (s *Day16Solution) getMazeInfoMap(maze util.Matrix[rune]) util.Matrix[CellInfo] {
-	mazeInfo := make(util.Matrix[CellInfo], len(maze))
-	for i, row := range maze {
-		mazeInfo[i] = make([]CellInfo, len(row))
-		for j, cell := range row {
-			mazeInfo[i][j] = CellInfo{cell, make(map[util.Vector]int)}
-		}
-	}
-	return mazeInfo
+	return s.solutionData.cellsOnPath.Len(), nil
 }
 
 // getStartAndEnd returns the start and end positions in the maze.
-func getStartAndEnd(maze util.Matrix[rune]) (*util.Vector, *util.Vector) {
-	var start, end *util.Vector
+func getStartAndEnd(maze util.Matrix[rune]) (util.Vector, util.Vector) {
+	var start, end util.Vector
 	for i, row := range maze {
 		for j, cell := range row {
 			if cell == 'S' {
@@ -0,0 +1,99 @@
+package day17
+
+import (
+	"slices"
+	"testing"
+)
+
+// The examples in these tests come from the problem statement.
+
+func TestInstructions_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction Instruction
+		operand     int
+		registers   Registers
+		want        Registers
+		wantOutput  []int
+	}{
+		{"adv literal", &AdvInstruction{}, 2, Registers{A: 20}, Registers{A: 5}, []int{}},
+		{"adv combo register", &AdvInstruction{}, 5, Registers{A: 64, B: 3}, Registers{A: 8, B: 3}, []int{}},
+		{"bxl", &BxlInstruction{}, 7, Registers{B: 29}, Registers{B: 26}, []int{}},
+		{"bst", &BstInstruction{}, 6, Registers{C: 9}, Registers{B: 1, C: 9}, []int{}},
+		{"bxc", &BxcInstruction{}, 0, Registers{B: 2024, C: 43690}, Registers{B: 44354, C: 43690}, []int{}},
+		{"out", &OutInstruction{}, 4, Registers{A: 13}, Registers{A: 13}, []int{5}},
+		{"bdv", &BdvInstruction{}, 3, Registers{A: 64}, Registers{A: 64, B: 8}, []int{}},
+		{"cdv", &CdvInstruction{}, 1, Registers{A: 64}, Registers{A: 64, C: 32}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewProgramState(tt.registers)
+			output, err := tt.instruction.Execute(tt.operand, state)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if state.Registers != tt.want {
+				t.Errorf("Registers = %+v, want %+v", state.Registers, tt.want)
+			}
+			if !slices.Equal(output, tt.wantOutput) {
+				t.Errorf("Execute() = %v, want %v", output, tt.wantOutput)
+			}
+			if state.InstructionPointer != 2 {
+				t.Errorf("InstructionPointer = %d, want 2", state.InstructionPointer)
+			}
+		})
+	}
+}
+
+func TestJnzInstruction_Execute(t *testing.T) {
+	state := NewProgramState(Registers{A: 1})
+	state.InstructionPointer = 4
+	(&JnzInstruction{}).Execute(0, state)
+	if state.InstructionPointer != 0 {
+		t.Errorf("InstructionPointer with A = 1 is %d, want 0", state.InstructionPointer)
+	}
+	state = NewProgramState(Registers{A: 0})
+	state.InstructionPointer = 4
+	(&JnzInstruction{}).Execute(0, state)
+	if state.InstructionPointer != 6 {
+		t.Errorf("InstructionPointer with A = 0 is %d, want 6", state.InstructionPointer)
+	}
+}
+
+func TestProgram_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		registers    Registers
+		instructions []int
+		want         Registers
+		wantOutput   []int
+	}{
+		{"set B from C", Registers{C: 9}, []int{2, 6}, Registers{B: 1, C: 9}, []int{}},
+		{"output A", Registers{A: 10}, []int{5, 0, 5, 1, 5, 4}, Registers{A: 10}, []int{0, 1, 2}},
+		{"loop", Registers{A: 2024}, []int{0, 1, 5, 4, 3, 0}, Registers{A: 0}, []int{4, 2, 5, 6, 7, 7, 7, 7, 3, 1, 0}},
+		{"xor B literal", Registers{B: 29}, []int{1, 7}, Registers{B: 26}, []int{}},
+		{"xor B and C", Registers{B: 2024, C: 43690}, []int{4, 0}, Registers{B: 44354, C: 43690}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := NewProgram(tt.instructions, NewProgramState(tt.registers))
+			output, err := program.Run(true)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if program.State.Registers != tt.want {
+				t.Errorf("Registers = %+v, want %+v", program.State.Registers, tt.want)
+			}
+			if !slices.Equal(output, tt.wantOutput) {
+				t.Errorf("Run() = %v, want %v", output, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestProgram_RunInvalidInstruction(t *testing.T) {
+	program := NewProgram([]int{8, 0}, NewProgramState(Registers{}))
+	if _, err := program.Run(true); err == nil {
+		t.Errorf("Run() error = nil, want error")
+	}
+}
@@ -41,6 +41,7 @@ package day17
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -53,6 +54,17 @@ type Day17Solution struct {
 	program *Program
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   17,
+		Title: "Chronospatial Computer",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay17Solution(filepath)
+		},
+	})
+}
+
 func NewDay17Solution(filename string) (*Day17Solution, error) {
 	registers := Registers{}
 	program := make([]int, 0)
@@ -77,24 +89,24 @@ func NewDay17Solution(filename string) (*Day17Solution, error) {
 	return &Day17Solution{NewProgram(program, NewProgramState(registers))}, err
 }
 
-func (s *Day17Solution) PartOneAnswer() (int, error) {
+func (s *Day17Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	output, err := s.program.Copy().Run(true)
 	if err != nil {
-		return 0, err
+		return util.Answer{}, err
 	}
-	fmt.Println("Registers:", s.program.State.Registers)
-	fmt.Println("Output:", s.arrToString(output))
-	return 0, nil
+	return util.NewStringAnswer(s.arrToString(output)), nil
 }
 
-func (s *Day17Solution) PartTwoAnswer() (int, error) {
+func (s *Day17Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	// the search changes the program's state, so it gets a copy of its own
+	program := s.program.Copy()
 	for i := 0; i < 128; i++ {
-		a, found := s.findSelfPrintingProgram(s.program, len(s.program.Instructions)-1, i)
+		a, found := s.findSelfPrintingProgram(program, len(program.Instructions)-1, i)
 		if found {
-			return a, nil
+			return util.NewIntAnswer(a), nil
 		}
 	}
-	return 0, fmt.Errorf("no self-printing program found")
+	return util.Answer{}, fmt.Errorf("no self-printing program found")
 }
 
 // findSelfPrintingProgram finds a program with an initial value for register A
@@ -2,10 +2,10 @@
 //
 // https://adventofcode.com/2024/day/18
 //
-// Part 1: I decided to finally implement a priority queue in util. From there,
-// I used djikstra's algorithm to find the shortest path from the start to the.
-// end. I used a matrix to represent the memory space and a matrix of CellInfo
-// to represent the shortest path to each cell.
+// Part 1: I first implemented a priority queue in util, and used djikstra's
+// algorithm to find the shortest path from the start to the end. Every step
+// costs the same, though, so a breadth first search over the free cells of the
+// memory space finds it just as well.
 //
 // Part 2 Idea: If we think of the memory map as a graph, the first byte that
 // prevents an exit is the first byte that creates a bipartite graph.
@@ -17,41 +17,48 @@ package day18
 
 import (
 	"advent/util"
+	"advent/util/search"
 	"bufio"
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 )
 
-const MemoryHeight = 71
-const MemoryWidth = 71
-
-const ByteCount = 1024
-
-type CellInfo struct {
-	pos          util.Vector
-	shortestPath map[util.Vector]bool
-	sym          rune
-}
-
-func (c CellInfo) Compare(other CellInfo) int {
-	return len(c.shortestPath) - len(other.shortestPath)
-}
-
 type Day18Solution struct {
 	memorySpace  util.Matrix[rune]
-	fallingBytes []*util.Vector
+	fallingBytes []util.Vector
+	// byteCount is how many bytes have fallen in part one.
+	byteCount int
+}
+
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   18,
+		Title: "RAM Run",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay18Solution(filepath, config.Params["width"], config.Params["height"], config.Params["bytes"])
+		},
+		Params: map[string]util.Params{
+			"input": {"width": 71, "height": 71, "bytes": 1024},
+			"test":  {"width": 7, "height": 7, "bytes": 12},
+		},
+	})
 }
 
-func NewDay18Solution(filename string) (*Day18Solution, error) {
+// NewDay18Solution returns the solution for the bytes in filename, falling
+// into a memory space of the given width and height, of which byteCount fall
+// in part one.
+func NewDay18Solution(filename string, width, height, byteCount int) (*Day18Solution, error) {
 	memorySpace := util.NewMatrix[rune]()
-	for i := 0; i < MemoryHeight; i++ {
-		memorySpace = append(memorySpace, make([]rune, MemoryWidth))
-		for j := 0; j < MemoryWidth; j++ {
+	for i := 0; i < height; i++ {
+		memorySpace = append(memorySpace, make([]rune, width))
+		for j := 0; j < width; j++ {
 			memorySpace[i][j] = '.'
 		}
 	}
-	fallingBytes := make([]*util.Vector, 0)
+	fallingBytes := make([]util.Vector, 0)
 	err := util.ProcessFile(filename, func(scanner *bufio.Scanner) error {
 		for scanner.Scan() {
 			line := scanner.Text()
@@ -68,110 +75,90 @@ func NewDay18Solution(filename string) (*Day18Solution, error) {
 		}
 		return scanner.Err()
 	})
-	return &Day18Solution{memorySpace, fallingBytes}, err
+	if err == nil && byteCount > len(fallingBytes) {
+		err = fmt.Errorf("only %d bytes fall, fewer than the %d bytes of part one", len(fallingBytes), byteCount)
+	}
+	return &Day18Solution{memorySpace, fallingBytes, byteCount}, err
 }
 
-func (s *Day18Solution) PartOneAnswer() (int, error) {
-	s.simulateXBytes(s.memorySpace, 0, ByteCount, s.fallingBytes)
+func (s *Day18Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	memorySpace := s.memorySpace.Copy()
+	s.simulateXBytes(memorySpace, 0, s.byteCount, s.fallingBytes)
 	start := util.NewVector(0, 0)
-	end := util.NewVector(MemoryWidth-1, MemoryHeight-1)
-	shortestPath := s.findShortestPath(s.memorySpace, start, end)
+	end := s.exit()
+	shortestPath := s.findShortestPath(memorySpace, start, end)
 	if shortestPath == nil {
-		return -1, fmt.Errorf("no path found")
+		return util.Answer{}, fmt.Errorf("no path found")
 	}
-	return len(shortestPath), nil
+	// the path includes both the start and the end, which is one more position
+	// than the number of steps
+	return util.NewIntAnswer(shortestPath.Len() - 1), nil
 }
 
-func (s *Day18Solution) PartTwoAnswer() (int, error) {
+func (s *Day18Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	memorySpace := s.memorySpace.Copy()
+	// part one shows there is still a path once the first bytes have fallen,
+	// so the search can start from there
+	s.simulateXBytes(memorySpace, 0, s.byteCount, s.fallingBytes)
 	start := util.NewVector(0, 0)
-	end := util.NewVector(MemoryWidth-1, MemoryHeight-1)
-	currentPath := s.findShortestPath(s.memorySpace, start, end)
+	end := s.exit()
+	currentPath := s.findShortestPath(memorySpace, start, end)
 	lastByteToFall := 0
 	for i := 0; i < len(s.fallingBytes); i++ {
-		if currentPath[*s.fallingBytes[i]] {
-			s.simulateXBytes(s.memorySpace, lastByteToFall, i+1, s.fallingBytes)
+		if err := util.CheckContext(ctx); err != nil {
+			return util.Answer{}, err
+		}
+		if currentPath.Contains(s.fallingBytes[i]) {
+			s.simulateXBytes(memorySpace, lastByteToFall, i+1, s.fallingBytes)
 			lastByteToFall = i
-			currentPath = s.findShortestPath(s.memorySpace, start, end)
+			currentPath = s.findShortestPath(memorySpace, start, end)
 			if currentPath == nil {
-				fmt.Printf("blocking byte: %d,%d\n", s.fallingBytes[i].Y, s.fallingBytes[i].X)
-				return 0, nil
+				// bytes are stored as (row, column), but the answer is given as X,Y
+				return util.NewVectorAnswer(util.NewVector(s.fallingBytes[i].Y, s.fallingBytes[i].X)), nil
 			}
 		}
 	}
-	return -1, fmt.Errorf("no blocking byte found")
+	return util.Answer{}, fmt.Errorf("no blocking byte found")
+}
+
+// exit returns the position of the exit, in the corner opposite the start.
+// Positions are stored as (row, column).
+func (s *Day18Solution) exit() util.Vector {
+	return util.NewVector(len(s.memorySpace)-1, len(s.memorySpace[0])-1)
 }
 
 // simulateXBytes simulates the x bytes starting from start to fall into the
 // memory space.
-func (s *Day18Solution) simulateXBytes(memorySpace util.Matrix[rune], start, end int, bytes []*util.Vector) {
+func (s *Day18Solution) simulateXBytes(memorySpace util.Matrix[rune], start, end int, bytes []util.Vector) {
 	for i := start; i < end; i++ {
 		position := bytes[i]
 		memorySpace.Set(position, '#')
 	}
 }
 
-// findShortestPath finds the shortest path from the start to the end in the
-// memory space.
-func (s *Day18Solution) findShortestPath(memorySpace util.Matrix[rune], start, end *util.Vector) map[util.Vector]bool {
-	memorySpaceMap := s.getMemorySpaceMap(memorySpace)
-	pq := util.NewArrayPriorityQueue[CellInfo]()
-	visited := make(map[util.Vector]bool)
-	pq.Insert(CellInfo{pos: *start, shortestPath: make(map[util.Vector]bool), sym: '.'})
-	for !pq.IsEmpty() {
-		current := pq.Remove()
-		if current.pos.Equals(end) {
-			return current.shortestPath
-		}
-		if visited[current.pos] {
-			continue
-		}
-		visited[current.pos] = true
-		neighbors := s.getValidNeighbors(memorySpaceMap, &current.pos)
-		for _, neighbor := range neighbors {
-			neighborInfo := memorySpaceMap.Get(neighbor)
-			if !visited[*neighbor] && (neighborInfo.shortestPath == nil || len(neighborInfo.shortestPath) > len(current.shortestPath)+1) {
-				neighborInfo.shortestPath = s.copyMap(current.shortestPath)
-				neighborInfo.shortestPath[current.pos] = true
-				memorySpaceMap.Set(neighbor, neighborInfo)
-				pq.Insert(neighborInfo)
-			}
-		}
+// findShortestPath finds a shortest path from the start to the end in the
+// memory space, and returns the positions on it, including the start and the
+// end. Returns nil if there is no path.
+func (s *Day18Solution) findShortestPath(memorySpace util.Matrix[rune], start, end util.Vector) util.Set[util.Vector] {
+	paths := search.BFS(start, func(position util.Vector) []util.Vector {
+		return s.getValidNeighbors(memorySpace, position)
+	})
+	path := paths.Path(end)
+	if path == nil {
+		return nil
 	}
-	return nil
+	return util.NewSet(path...)
 }
 
 // getValidNeighbors returns the valid neighbors of a position in the memory
 // space. A valid neighbor is a position that is within the bounds of the memory
 // space and is a '.'.
-func (s *Day18Solution) getValidNeighbors(memorySpace util.Matrix[CellInfo], position *util.Vector) []*util.Vector {
-	neighbors := make([]*util.Vector, 0)
-	for _, direction := range util.SimpleDirections {
-		neighbor := position.Add(direction)
-		if memorySpace.PosInBounds(neighbor) && memorySpace.Get(neighbor).sym == '.' {
+func (s *Day18Solution) getValidNeighbors(memorySpace util.Matrix[rune], position util.Vector) []util.Vector {
+	neighbors := make([]util.Vector, 0, len(util.SimpleDirections))
+	for _, neighbor := range position.Neighbors4() {
+		if memorySpace.PosInBounds(neighbor) && memorySpace.Get(neighbor) == '.' {
 			neighbors = append(neighbors, neighbor)
 		}
 	}
 	return neighbors
 }
-
-func (s *Day18Solution) getMemorySpaceMap(memorySpace util.Matrix[rune]) util.Matrix[CellInfo] {
-	memorySpaceMap := util.NewMatrix[CellInfo]()
-	for i := 0; i < MemoryHeight; i++ {
-		memorySpaceMap = append(memorySpaceMap, make([]CellInfo, MemoryWidth))
-		for j := 0; j < MemoryWidth; j++ {
-			position := util.NewVector(i, j)
-			if memorySpace.Get(position) == '.' {
-				memorySpaceMap.Set(position, CellInfo{pos: *position, shortestPath: nil, sym: '.'})
-			}
-		}
-	}
-	return memorySpaceMap
-}
-
-func (s *Day18Solution) copyMap(orig map[util.Vector]bool) map[util.Vector]bool {
-	copy := make(map[util.Vector]bool)
-	for key, value := range orig {
-		copy[key] = value
-	}
-	return copy
-}
@@ -27,24 +27,33 @@ package day19
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"strings"
 )
 
 type Day19Solution struct {
-	patterns       map[string]bool
+	patterns       util.Set[string]
 	desiredDesigns []string
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   19,
+		Title: "Linen Layout",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay19Solution(filepath)
+		},
+	})
+}
+
 func NewDay19Solution(filename string) (*Day19Solution, error) {
-	patterns := make(map[string]bool)
+	patterns := util.NewSet[string]()
 	desiredDesigns := make([]string, 0)
 	err := util.ProcessFile(filename, func(scanner *bufio.Scanner) error {
 		// first line is the patterns
 		scanner.Scan()
-		patternsList := strings.Split(scanner.Text(), ", ")
-		for _, pattern := range patternsList {
-			patterns[pattern] = true
-		}
+		patterns.Add(strings.Split(scanner.Text(), ", ")...)
 		// then comes a blank line
 		scanner.Scan()
 		// and then the desired designs
@@ -56,17 +65,17 @@ func NewDay19Solution(filename string) (*Day19Solution, error) {
 	return &Day19Solution{patterns, desiredDesigns}, err
 }
 
-func (s *Day19Solution) PartOneAnswer() (int, error) {
-	return s.numDesignsPossible(s.desiredDesigns, s.patterns), nil
+func (s *Day19Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.numDesignsPossible(s.desiredDesigns, s.patterns)), nil
 }
 
-func (s *Day19Solution) PartTwoAnswer() (int, error) {
-	return s.totalNumArrangementsPossible(s.desiredDesigns, s.patterns), nil
+func (s *Day19Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewIntAnswer(s.totalNumArrangementsPossible(s.desiredDesigns, s.patterns)), nil
 }
 
 // numDesignsPossible returns the number of designs in designs that can be arranged
 // using patterns from patterns.
-func (s *Day19Solution) numDesignsPossible(designs []string, patterns map[string]bool) int {
+func (s *Day19Solution) numDesignsPossible(designs []string, patterns util.Set[string]) int {
 	count := 0
 	for _, design := range designs {
 		if s.designIsPossible(design, patterns) {
@@ -78,7 +87,7 @@ func (s *Day19Solution) numDesignsPossible(designs []string, patterns map[string
 
 // totalNumArrangementsPossible returns the total number of arrangements possible for each design
 // in designs, summed together.
-func (s *Day19Solution) totalNumArrangementsPossible(designs []string, patterns map[string]bool) int {
+func (s *Day19Solution) totalNumArrangementsPossible(designs []string, patterns util.Set[string]) int {
 	total := 0
 	for _, design := range designs {
 		total += s.numArrangementsPossible(design, patterns)
@@ -88,18 +97,18 @@ func (s *Day19Solution) totalNumArrangementsPossible(designs []string, patterns
 
 // designIsPossible returns true if and only if the design can be arranged using
 // the patterns.
-func (s *Day19Solution) designIsPossible(design string, patterns map[string]bool) bool {
+func (s *Day19Solution) designIsPossible(design string, patterns util.Set[string]) bool {
 	return s.numArrangementsPossible(design, patterns) > 0
 }
 
 // numArrangementsPossible returns the number of ways to arrange the design using patterns.
-func (s *Day19Solution) numArrangementsPossible(design string, patterns map[string]bool) int {
+func (s *Day19Solution) numArrangementsPossible(design string, patterns util.Set[string]) int {
 	counts := make([]int, len(design)+1)
 	// this is the priming step; 0 is not a valid substring, but a way to start
 	// the dynamic programming.
 	counts[0] = 1
 	for i := 1; i <= len(design); i++ {
-		for pattern := range patterns {
+		for pattern := range patterns.All() {
 			if strings.HasSuffix(design[:i], pattern) {
 				counts[i] += counts[i-len(pattern)]
 			}
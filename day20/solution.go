@@ -2,9 +2,9 @@
 //
 // https://adventofcode.com/2024/day/20
 //
-// Part 1: I decided to preprocess the solution by running a djikstra's search
-// for the shortest path from the end to each cell reachable, so we can run
-// the following algorithm:
+// Part 1: I decided to preprocess the solution by running a breadth first
+// search for the shortest path from the end to each cell reachable, so we can
+// run the following algorithm:
 //   - for each cell 'cell':
 //   - for each reachable cell 'nextCell' with a manhattan distance of <= 2
 //   - if nextCell.distanceToEnd - cell.distanceToEnd > 100:
@@ -16,9 +16,10 @@ package day20
 
 import (
 	"advent/util"
+	"advent/util/search"
+	"context"
 )
 
-const ShortcutThreshold = 100
 const PartOneCheatTime = 2
 const PartTwoCheatTime = 20
 const WallCell = '#'
@@ -26,59 +27,60 @@ const WallCell = '#'
 type RacetrackCell struct {
 	sym           rune
 	distanceToEnd int
-	pos           *util.Vector
-}
-
-func (c RacetrackCell) Compare(other RacetrackCell) int {
-	return c.distanceToEnd - other.distanceToEnd
+	pos           util.Vector
 }
 
 type Day20Solution struct {
 	racetrack  util.Matrix[rune]
-	start, end *util.Vector
+	start, end util.Vector
+	// threshold is the least time a shortcut must save to be counted.
+	threshold int
+}
+
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   20,
+		Title: "Race Condition",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay20Solution(filepath, config.Params["threshold"])
+		},
+		Params: map[string]util.Params{
+			"input": {"threshold": 100},
+			"test":  {"threshold": 50},
+		},
+	})
 }
 
-func NewDay20Solution(filename string) (*Day20Solution, error) {
+func NewDay20Solution(filename string, threshold int) (*Day20Solution, error) {
 	racetrack, err := util.ParseMatrixFromFile(filename, func(r rune) rune {
 		return r
 	})
 	start, end := findStartAndEnd(racetrack)
-	return &Day20Solution{racetrack, start, end}, err
+	return &Day20Solution{racetrack, start, end, threshold}, err
 }
 
-func (s *Day20Solution) PartOneAnswer() (int, error) {
+func (s *Day20Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	racetrackSearch := s.getRacetrackSearch(s.racetrack)
 	s.shortestPathsToEnd(racetrackSearch, s.end)
-	return s.getShortcutCount(racetrackSearch, PartOneCheatTime, ShortcutThreshold), nil
+	return util.NewIntAnswer(s.getShortcutCount(racetrackSearch, PartOneCheatTime, s.threshold)), nil
 }
 
-func (s *Day20Solution) PartTwoAnswer() (int, error) {
+func (s *Day20Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	racetrackSearch := s.getRacetrackSearch(s.racetrack)
 	s.shortestPathsToEnd(racetrackSearch, s.end)
-	return s.getShortcutCount(racetrackSearch, PartTwoCheatTime, ShortcutThreshold), nil
+	return util.NewIntAnswer(s.getShortcutCount(racetrackSearch, PartTwoCheatTime, s.threshold)), nil
 }
 
-func (s *Day20Solution) shortestPathsToEnd(racetrack util.Matrix[RacetrackCell], end *util.Vector) {
-	visited := make(map[util.Vector]bool)
-	toVisit := util.NewArrayPriorityQueue[RacetrackCell]()
-	endCell := racetrack.Get(end)
-	endCell.distanceToEnd = 0
-	racetrack.Set(end, endCell)
-	toVisit.Insert(endCell)
-	for !toVisit.IsEmpty() {
-		cell := toVisit.Remove()
-		if visited[*cell.pos] {
-			continue
-		}
-		visited[*cell.pos] = true
-		for neighborPos := range s.getEmptyNeighbors(racetrack, cell.pos) {
-			neighbor := racetrack.Get(neighborPos)
-			if neighbor.distanceToEnd == -1 || cell.distanceToEnd+1 < neighbor.distanceToEnd {
-				neighbor.distanceToEnd = cell.distanceToEnd + 1
-				racetrack.Set(neighbor.pos, neighbor)
-				toVisit.Insert(neighbor)
-			}
-		}
+// shortestPathsToEnd fills in the distance to the end of every cell of the
+// racetrack the end can be reached from.
+func (s *Day20Solution) shortestPathsToEnd(racetrack util.Matrix[RacetrackCell], end util.Vector) {
+	paths := search.BFS(end, func(pos util.Vector) []util.Vector {
+		return s.getEmptyNeighbors(racetrack, pos)
+	})
+	for pos := range paths.Reached() {
+		distance, _ := paths.Cost(pos)
+		racetrack[pos.X][pos.Y].distanceToEnd = distance
 	}
 }
 
@@ -104,12 +106,11 @@ func (s *Day20Solution) getShortcutCount(racetrack util.Matrix[RacetrackCell], c
 
 // getEmtpyNeighbors takes a racetrack, and a position to consider. It returns the neighbors with
 // empty squares.
-func (s *Day20Solution) getEmptyNeighbors(racetrack util.Matrix[RacetrackCell], pos *util.Vector) map[*util.Vector]bool {
-	neighbors := make(map[*util.Vector]bool)
-	for _, d := range util.SimpleDirections {
-		neighbor := racetrack.Get(pos.Add(d))
-		if neighbor.sym != WallCell {
-			neighbors[neighbor.pos] = true
+func (s *Day20Solution) getEmptyNeighbors(racetrack util.Matrix[RacetrackCell], pos util.Vector) []util.Vector {
+	neighbors := make([]util.Vector, 0, len(util.SimpleDirections))
+	for _, neighborPos := range pos.Neighbors4() {
+		if racetrack.PosInBounds(neighborPos) && racetrack.Get(neighborPos).sym != WallCell {
+			neighbors = append(neighbors, neighborPos)
 		}
 	}
 	return neighbors
@@ -118,14 +119,14 @@ func (s *Day20Solution) getEmptyNeighbors(racetrack util.Matrix[RacetrackCell],
 // getCheatMoves takes a racetrack, a position, and a cheat time to consider.
 // It returns the positions of all positions that may be considered for a cheat
 // that lasts cheatTime picoseconds.
-func (s *Day20Solution) getCheatMoves(racetrack util.Matrix[RacetrackCell], pos *util.Vector, cheatTime int) map[RacetrackCell]int {
+func (s *Day20Solution) getCheatMoves(racetrack util.Matrix[RacetrackCell], pos util.Vector, cheatTime int) map[RacetrackCell]int {
 	neighbors := make(map[RacetrackCell]int)
 	for i := pos.X - cheatTime; i <= pos.X+cheatTime; i++ {
 		for j := pos.Y - cheatTime; j <= pos.Y+cheatTime; j++ {
 			cellPos := util.NewVector(i, j)
-			if !pos.Equals(cellPos) && racetrack.PosInBounds(cellPos) {
+			if pos != cellPos && racetrack.PosInBounds(cellPos) {
 				cell := racetrack.Get(cellPos)
-				distance := cell.pos.GetManhattanDistance(pos).GetManhattanMagnitude()
+				distance := cell.pos.ManhattanDistance(pos)
 				if cell.sym != WallCell && distance <= cheatTime {
 					neighbors[cell] = distance
 				}
@@ -148,8 +149,8 @@ func (s *Day20Solution) getRacetrackSearch(racetrack util.Matrix[rune]) util.Mat
 
 // findStartAndEnd finds the symbols S and E on the racetrack. It then returns
 // the positions of both in the form (startVector, endVector)
-func findStartAndEnd(racetrack util.Matrix[rune]) (*util.Vector, *util.Vector) {
-	var start, end *util.Vector
+func findStartAndEnd(racetrack util.Matrix[rune]) (util.Vector, util.Vector) {
+	var start, end util.Vector
 	for i := range racetrack {
 		for j, cell := range racetrack[i] {
 			if cell == 'S' {
@@ -45,11 +45,12 @@ package day21
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
 	"strconv"
 )
 
-var NumberPadPositions = map[rune]*util.Vector{
+var NumberPadPositions = map[rune]util.Vector{
 	'7': util.NewVector(0, 0),
 	'8': util.NewVector(1, 0),
 	'9': util.NewVector(2, 0),
@@ -97,7 +98,7 @@ var DirectionalTransitionSequences = map[Transition]string{
 }
 
 // FOR TESTING
-var DirectionalVectors = map[rune]*util.Vector{
+var DirectionalVectors = map[rune]util.Vector{
 	'^': util.NewVector(0, -1),
 	'<': util.NewVector(-1, 0),
 	'v': util.NewVector(0, 1),
@@ -108,6 +109,17 @@ type Day21Solution struct {
 	codes []string
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   21,
+		Title: "Keypad Conundrum",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay21Solution(filepath)
+		},
+	})
+}
+
 func NewDay21Solution(filename string) (*Day21Solution, error) {
 	codes := make([]string, 0)
 	err := util.ProcessFile(filename, func(scanner *bufio.Scanner) error {
@@ -119,12 +131,20 @@ func NewDay21Solution(filename string) (*Day21Solution, error) {
 	return &Day21Solution{codes}, err
 }
 
-func (s *Day21Solution) PartOneAnswer() (int, error) {
-	return s.getCodeComplexitySum(s.codes, 2)
+func (s *Day21Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	sum, err := s.getCodeComplexitySum(s.codes, 2)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(sum), nil
 }
 
-func (s *Day21Solution) PartTwoAnswer() (int, error) {
-	return s.getCodeComplexitySum(s.codes, 25)
+func (s *Day21Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	sum, err := s.getCodeComplexitySum(s.codes, 25)
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(sum), nil
 }
 
 // getCodeComplexitySum returns the sum of the complexities of each
@@ -183,7 +203,7 @@ func (s *Day21Solution) getNumericalRobotMoves(code string) ([]string, error) {
 		}
 		size := moves.Size()
 		for range size {
-			baseSequence := moves.Remove()
+			baseSequence, _ := moves.Remove()
 			for _, sequence := range sequences {
 				moves.Insert(baseSequence + sequence)
 			}
@@ -226,7 +246,7 @@ func (s *Day21Solution) getNumberPadSequences(start, end rune) ([]string, error)
 	if !ok {
 		return nil, fmt.Errorf("code not found in positions: '%v'", end)
 	}
-	distance := endPosition.GetManhattanDistance(startPosition)
+	distance := endPosition.Sub(startPosition)
 	xString, yString := s.getDirectionalSegments(distance)
 	// order: left, (up, right), down
 	if xString == "" {
@@ -245,7 +265,7 @@ func (s *Day21Solution) getNumberPadSequences(start, end rune) ([]string, error)
 // getDirectionalCharacters takes a vector, and returns the segments needed
 // to traverse horizontally and vertically. If no traversal is needed for one,
 // an empty string is chosen.
-func (s *Day21Solution) getDirectionalSegments(v *util.Vector) (string, string) {
+func (s *Day21Solution) getDirectionalSegments(v util.Vector) (string, string) {
 	xRune := rune(-1)
 	yRune := rune(-1)
 	if v.X < 0 {
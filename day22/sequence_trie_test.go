@@ -0,0 +1,89 @@
+package day22
+
+import "testing"
+
+func TestSequenceTrie_InsertAndBananas(t *testing.T) {
+	trie := NewSequenceTrie(4)
+	if err := trie.Insert([]int{-2, 1, -1, 3}, 7); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	bananas, err := trie.Bananas([]int{-2, 1, -1, 3})
+	if err != nil {
+		t.Fatalf("Bananas() error = %v", err)
+	}
+	if bananas != 7 {
+		t.Errorf("Bananas() = %d, want 7", bananas)
+	}
+}
+
+func TestSequenceTrie_InsertKeepsFirstBananas(t *testing.T) {
+	trie := NewSequenceTrie(4)
+	trie.Insert([]int{-2, 1, -1, 3}, 7)
+	trie.Insert([]int{-2, 1, -1, 3}, 9)
+	bananas, _ := trie.Bananas([]int{-2, 1, -1, 3})
+	if bananas != 7 {
+		t.Errorf("Bananas() = %d, want 7", bananas)
+	}
+}
+
+func TestSequenceTrie_InsertWrongLength(t *testing.T) {
+	trie := NewSequenceTrie(4)
+	if err := trie.Insert([]int{1, 2, 3}, 7); err == nil {
+		t.Errorf("Insert() error = nil, want error")
+	}
+}
+
+func TestSequenceTrie_BananasMissingSequence(t *testing.T) {
+	trie := NewSequenceTrie(4)
+	trie.Insert([]int{-2, 1, -1, 3}, 7)
+	if _, err := trie.Bananas([]int{-2, 1, -1, 2}); err == nil {
+		t.Errorf("Bananas() error = nil, want error")
+	}
+}
+
+func TestSequenceTrie_MaxBananas(t *testing.T) {
+	trie := NewSequenceTrie(4)
+	trie.Insert([]int{-2, 1, -1, 3}, 7)
+	trie.Insert([]int{1, 1, 1, 1}, 9)
+	trie.Insert([]int{1, 1, 1, 2}, 2)
+	if got := trie.MaxBananas(); got != 9 {
+		t.Errorf("MaxBananas() = %d, want 9", got)
+	}
+}
+
+func TestSequenceTrie_MergeInto(t *testing.T) {
+	trie := NewSequenceTrie(4)
+	trie.Insert([]int{-2, 1, -1, 3}, 7)
+	trie.Insert([]int{1, 1, 1, 1}, 9)
+	other := NewSequenceTrie(4)
+	other.Insert([]int{-2, 1, -1, 3}, 6)
+	other.Insert([]int{0, 0, 0, 0}, 1)
+	if err := trie.MergeInto(other); err != nil {
+		t.Fatalf("MergeInto() error = %v", err)
+	}
+	tests := []struct {
+		sequence []int
+		want     int
+	}{
+		{[]int{-2, 1, -1, 3}, 13},
+		{[]int{1, 1, 1, 1}, 9},
+		{[]int{0, 0, 0, 0}, 1},
+	}
+	for _, tt := range tests {
+		bananas, err := trie.Bananas(tt.sequence)
+		if err != nil {
+			t.Errorf("Bananas(%v) error = %v", tt.sequence, err)
+		} else if bananas != tt.want {
+			t.Errorf("Bananas(%v) = %d, want %d", tt.sequence, bananas, tt.want)
+		}
+	}
+	if got := trie.MaxBananas(); got != 13 {
+		t.Errorf("MaxBananas() = %d, want 13", got)
+	}
+}
+
+func TestSequenceTrie_MergeIntoMismatchedLength(t *testing.T) {
+	if err := NewSequenceTrie(4).MergeInto(NewSequenceTrie(3)); err == nil {
+		t.Errorf("MergeInto() error = nil, want error")
+	}
+}
@@ -17,6 +17,8 @@ package day22
 import (
 	"advent/util"
 	"bufio"
+	"context"
+	"slices"
 	"strconv"
 )
 
@@ -27,15 +29,29 @@ const FirstShiftUp = 6
 const FirstShiftDown = 5
 const SecondShiftUp = 11
 
-const DailyNewSecrets = 2000
-
 const SequenceLength = 4
 
 type Day22Solution struct {
 	initialSecrets []int
+	// dailyNewSecrets is how many secrets each buyer generates in a day.
+	dailyNewSecrets int
+}
+
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   22,
+		Title: "Monkey Market",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay22Solution(filepath, config.Params["secrets"])
+		},
+		Params: map[string]util.Params{
+			"input": {"secrets": 2000},
+		},
+	})
 }
 
-func NewDay22Solution(filename string) (*Day22Solution, error) {
+func NewDay22Solution(filename string, dailyNewSecrets int) (*Day22Solution, error) {
 	initialSecrets := make([]int, 0)
 	err := util.ProcessFile(filename, func(scanner *bufio.Scanner) error {
 		for scanner.Scan() {
@@ -50,24 +66,30 @@ func NewDay22Solution(filename string) (*Day22Solution, error) {
 		}
 		return scanner.Err()
 	})
-	return &Day22Solution{initialSecrets}, err
+	return &Day22Solution{initialSecrets, dailyNewSecrets}, err
 }
 
-func (s *Day22Solution) PartOneAnswer() (int, error) {
+func (s *Day22Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	newSecrets := make([]int, len(s.initialSecrets))
 	for i, secret := range s.initialSecrets {
-		newSecrets[i] = s.nthSecret(secret, DailyNewSecrets)
+		if err := util.CheckContext(ctx); err != nil {
+			return util.Answer{}, err
+		}
+		newSecrets[i] = s.nthSecret(secret, s.dailyNewSecrets)
 	}
-	return util.SliceSum(newSecrets), nil
+	return util.NewIntAnswer(util.SliceSum(newSecrets)), nil
 }
 
-func (s *Day22Solution) PartTwoAnswer() (int, error) {
+func (s *Day22Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	sequenceTrie := NewSequenceTrie(SequenceLength)
 	for _, initialSecret := range s.initialSecrets {
-		prices := s.getPrices(initialSecret, DailyNewSecrets)
+		if err := util.CheckContext(ctx); err != nil {
+			return util.Answer{}, err
+		}
+		prices := s.getPrices(initialSecret, s.dailyNewSecrets)
 		s.addNewPrices(sequenceTrie, prices)
 	}
-	return sequenceTrie.MaxBananas(), nil
+	return util.NewIntAnswer(sequenceTrie.MaxBananas()), nil
 }
 
 // getPrices returns n prices in an array. initialSecret is the first secret in
@@ -121,11 +143,15 @@ func (s *Day22Solution) pruneNumber(n int) int {
 // value in prices is added on.
 func (s *Day22Solution) addNewPrices(trie *SequenceTrie, prices []int) {
 	sequence := util.NewArrayQueue[int]()
+	// window holds the sequence as a slice, reused so that each price doesn't
+	// allocate a new one
+	window := make([]int, 0, SequenceLength)
 	newTrie := NewSequenceTrie(SequenceLength)
 	for i := 1; i < len(prices); i++ {
 		sequence.Insert(prices[i] - prices[i-1])
 		if sequence.Size() == SequenceLength {
-			newTrie.Insert(sequence.ToArray(), prices[i])
+			window = slices.AppendSeq(window[:0], sequence.All())
+			newTrie.Insert(window, prices[i])
 			sequence.Remove()
 		}
 	}
@@ -18,18 +18,34 @@ package day23
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 )
 
-type NodeSetMap map[string]map[string]bool
-type Graph map[string]map[string]bool
+// NodeSetMap holds sets of nodes, keyed by their alphabetical string.
+type NodeSetMap map[string]util.Set[string]
+
+// Graph maps each node to the set of nodes it is connected to.
+type Graph map[string]util.Set[string]
 
 type Day23Solution struct {
 	lanGraph Graph
 }
 
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   23,
+		Title: "LAN Party",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay23Solution(filepath)
+		},
+	})
+}
+
 func NewDay23Solution(filename string) (*Day23Solution, error) {
 	lanGraph := make(Graph)
 	err := util.ProcessFile(filename, func(scanner *bufio.Scanner) error {
@@ -46,21 +62,20 @@ func NewDay23Solution(filename string) (*Day23Solution, error) {
 	return &Day23Solution{lanGraph}, err
 }
 
-func (s *Day23Solution) PartOneAnswer() (int, error) {
+func (s *Day23Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
 	denseSets := s.getDenseSetsOfSize(s.lanGraph, 3)
 	denseSets = s.filterForPrefix(denseSets, "t")
-	return len(denseSets), nil
+	return util.NewIntAnswer(len(denseSets)), nil
 }
 
-func (s *Day23Solution) PartTwoAnswer() (int, error) {
+func (s *Day23Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
 	largestDenseSets := s.getLargestDenseNodeSets(s.lanGraph)
 	if len(largestDenseSets) != 1 {
-		return 0, fmt.Errorf("there should be exactly one largest dense set, not %d", len(largestDenseSets))
-	}
-	for password := range largestDenseSets {
-		fmt.Printf("Password: %s\n", password)
+		return util.Answer{}, fmt.Errorf("there should be exactly one largest dense set, not %d", len(largestDenseSets))
 	}
-	return 0, nil
+	// the single set is keyed by its alphabetical string, which is the password
+	passwords := slices.Collect(maps.Keys(largestDenseSets))
+	return util.NewStringAnswer(passwords[0]), nil
 }
 
 // getDenseSetsOfSize returns all fully dense sets found in graph of size size.
@@ -107,10 +122,10 @@ func (s *Day23Solution) getDenseSets(graph Graph, oldDenseSets NodeSetMap) NodeS
 	denseSets := make(NodeSetMap, 0)
 	for node, connections := range graph {
 		for _, oldDenseSet := range oldDenseSets {
-			if _, ok := oldDenseSet[node]; !ok && s.isSubsetOf(connections, oldDenseSet) {
+			if !oldDenseSet.Contains(node) && oldDenseSet.IsSubsetOf(connections) {
 				// node is not in the oldDenseSet, and is connected to all nodes in oldDenseSet
-				denseSet := util.CopyMap(oldDenseSet)
-				denseSet[node] = true
+				denseSet := oldDenseSet.Clone()
+				denseSet.Add(node)
 				denseSetString := s.getNodeSetString(denseSet)
 				denseSets[denseSetString] = denseSet
 			}
@@ -119,20 +134,10 @@ func (s *Day23Solution) getDenseSets(graph Graph, oldDenseSets NodeSetMap) NodeS
 	return denseSets
 }
 
-// isSubsetOf returns true if and only if setTwo is a subset of setOne
-func (s *Day23Solution) isSubsetOf(setOne, setTwo map[string]bool) bool {
-	for el := range setTwo {
-		if _, ok := setOne[el]; !ok {
-			return false
-		}
-	}
-	return true
-}
-
 // containsPrefix returns true if and only if set contains at least one string
 // with the given prefix
-func (s *Day23Solution) containsPrefix(set map[string]bool, prefix string) bool {
-	for s := range set {
+func (s *Day23Solution) containsPrefix(set util.Set[string], prefix string) bool {
+	for s := range set.All() {
 		if strings.HasPrefix(s, prefix) {
 			return true
 		}
@@ -145,26 +150,23 @@ func (s *Day23Solution) containsPrefix(set map[string]bool, prefix string) bool
 func (s *Day23Solution) getFirstDenseSets(graph Graph) NodeSetMap {
 	denseSets := make(NodeSetMap)
 	for node := range graph {
-		denseSet := map[string]bool{node: true}
+		denseSet := util.NewSet(node)
 		denseSets[s.getNodeSetString(denseSet)] = denseSet
 	}
 	return denseSets
 }
 
-func (s *Day23Solution) getNodeSetString(nodes map[string]bool) string {
-	nodeList := make([]string, 0)
-	for node := range nodes {
-		nodeList = append(nodeList, node)
-	}
-	slices.Sort(nodeList)
-	return strings.Join(nodeList, ",")
+// getNodeSetString returns the nodes in alphabetical order, separated by
+// commas.
+func (s *Day23Solution) getNodeSetString(nodes util.Set[string]) string {
+	return strings.Join(util.Sorted(nodes), ",")
 }
 
 // addGraphConnection makes an asymmetric connection from left to right in
 // graph.
 func addGraphConnection(graph Graph, left, right string) {
 	if _, ok := graph[left]; !ok {
-		graph[left] = make(map[string]bool)
+		graph[left] = util.NewSet[string]()
 	}
-	graph[left][right] = true
+	graph[left].Add(right)
 }
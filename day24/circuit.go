@@ -45,6 +45,13 @@ func (c *Circuit) Solve(v string) (bool, error) {
 	return val, nil
 }
 
+// Copy returns a circuit with the same initial states and its own copy of the
+// gates, reset to its initial states. Solving or swapping gates in the copy
+// does not change c.
+func (c *Circuit) Copy() *Circuit {
+	return NewCircuit(c.initialStates, util.CopyMap(c.gates))
+}
+
 func (c *Circuit) Reset() {
 	c.states = util.CopyMap(c.initialStates)
 }
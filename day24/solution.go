@@ -23,7 +23,9 @@ package day24
 import (
 	"advent/util"
 	"bufio"
+	"context"
 	"fmt"
+	"log/slog"
 	"slices"
 	"strconv"
 	"strings"
@@ -41,9 +43,21 @@ var BitMap = map[bool]int{
 type Day24Solution struct {
 	circuit   *Circuit
 	variables map[string]bool
+	logger    *slog.Logger
 }
 
-func NewDay24Solution(filename string) (*Day24Solution, error) {
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  2024,
+		Day:   24,
+		Title: "Crossed Wires",
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return NewDay24Solution(filepath, config.Logger)
+		},
+	})
+}
+
+func NewDay24Solution(filename string, logger *slog.Logger) (*Day24Solution, error) {
 	initialStates := make(map[string]bool)
 	gates := make(map[string]*Gate)
 	variables := make(map[string]bool)
@@ -82,11 +96,15 @@ func NewDay24Solution(filename string) (*Day24Solution, error) {
 	// readableGates := getReadableGates(gates, readableNames)
 
 	circuit := NewCircuit(initialStates, gates)
-	return &Day24Solution{circuit, variables}, nil
+	return &Day24Solution{circuit, variables, logger}, nil
 }
 
-func (s *Day24Solution) PartOneAnswer() (int, error) {
-	return s.getAnswer(s.circuit, "z")
+func (s *Day24Solution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	answer, err := s.getAnswer(s.circuit.Copy(), "z")
+	if err != nil {
+		return util.Answer{}, err
+	}
+	return util.NewIntAnswer(answer), nil
 }
 
 // 27133763813069 + 20654586710041 = 47788350523110
@@ -104,28 +122,26 @@ func (s *Day24Solution) PartOneAnswer() (int, error) {
 //   - mmk and z24
 //   - ftq and z28
 //   - hqh and z38
-func (s *Day24Solution) PartTwoAnswer() (int, error) {
-	s.circuit.Reset()
+func (s *Day24Solution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	circuit := s.circuit.Copy()
 	rightAnswer := "1010110111011010010111101010000100011011100110"
 	swaps := []string{"vkq", "z11", "mmk", "z24", "pvb", "qdq", "hqh", "z38"}
 	slices.Sort(swaps)
-	s.swapGateOutputs(s.circuit, "vkq", "z11")
-	s.swapGateOutputs(s.circuit, "mmk", "z24")
-	s.swapGateOutputs(s.circuit, "pvb", "qdq")
-	s.swapGateOutputs(s.circuit, "hqh", "z38")
-	binaryStringAnswer, err := s.getBinaryStringAnswer(s.circuit, "z")
+	s.swapGateOutputs(circuit, "vkq", "z11")
+	s.swapGateOutputs(circuit, "mmk", "z24")
+	s.swapGateOutputs(circuit, "pvb", "qdq")
+	s.swapGateOutputs(circuit, "hqh", "z38")
+	binaryStringAnswer, err := s.getBinaryStringAnswer(circuit, "z")
 	if err != nil {
-		return 0, err
+		return util.Answer{}, err
 	}
-	fmt.Println(rightAnswer)
-	fmt.Println(binaryStringAnswer)
+	s.logger.Debug("compared sum after swaps", "want", rightAnswer, "got", binaryStringAnswer)
 	for i := len(rightAnswer) - 1; i >= 0; i-- {
 		if rightAnswer[i] != binaryStringAnswer[i] {
-			fmt.Println("wrong digit:", len(rightAnswer)-1-i)
+			s.logger.Debug("wrong digit", "digit", len(rightAnswer)-1-i)
 		}
 	}
-	fmt.Println("Solution:", strings.Join(swaps, ","))
-	return 0, nil
+	return util.NewStringAnswer(strings.Join(swaps, ",")), nil
 }
 
 // getAnswer takes the circuit and returns the integer value of all digits that
@@ -188,7 +204,7 @@ func (s *Day24Solution) printCircuitPath(circuit *Circuit, variable string) {
 		variables = append(variables, gate.Left, gate.Right)
 		s.printCircuitPathHelper(circuit, variables, 1)
 	}
-	fmt.Println(variable)
+	s.logger.Debug("circuit path", "variable", variable)
 }
 
 func (s *Day24Solution) printCircuitPathHelper(circuit *Circuit, variables []string, level int) {
@@ -202,14 +218,14 @@ func (s *Day24Solution) printCircuitPathHelper(circuit *Circuit, variables []str
 	if len(newVariables) != 0 {
 		s.printCircuitPathHelper(circuit, newVariables, level+1)
 	}
-	fmt.Printf("Level %d: %v\n", level, variables)
+	s.logger.Debug("circuit path", "level", level, "variables", variables)
 }
 
 func (s *Day24Solution) printTwoCircuitPaths(circuit *Circuit, vOne, vTwo string) {
 	vOnes := s.getNextLevel(circuit, []string{vOne})
 	vTwos := s.getNextLevel(circuit, []string{vTwo})
 	s.printTwoCircuitPathsHelper(circuit, vOnes, vTwos, 1)
-	fmt.Println(vOne, vTwo)
+	s.logger.Debug("circuit paths", "one", vOne, "two", vTwo)
 }
 
 func (s *Day24Solution) getNextLevel(circuit *Circuit, variables []string) []string {
@@ -241,5 +257,5 @@ func (s *Day24Solution) printTwoCircuitPathsHelper(circuit *Circuit, vOne, vTwo
 	if len(newVOne) != 0 || len(newVTwo) != 0 {
 		s.printTwoCircuitPathsHelper(circuit, newVOne, newVTwo, level+1)
 	}
-	fmt.Printf("Level %d: %v      %v\n", level, vOne, vTwo)
+	s.logger.Debug("circuit paths", "level", level, "one", vOne, "two", vTwo)
 }
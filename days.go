@@ -0,0 +1,30 @@
+package main
+
+// Each day's package registers itself with util.RegisterDay when imported. A
+// new day only needs to be added here.
+import (
+	_ "advent/day01"
+	_ "advent/day02"
+	_ "advent/day03"
+	_ "advent/day04"
+	_ "advent/day05"
+	_ "advent/day06"
+	_ "advent/day07"
+	_ "advent/day08"
+	_ "advent/day09"
+	_ "advent/day10"
+	_ "advent/day11"
+	_ "advent/day12"
+	_ "advent/day13"
+	_ "advent/day14"
+	_ "advent/day15"
+	_ "advent/day16"
+	_ "advent/day17"
+	_ "advent/day18"
+	_ "advent/day19"
+	_ "advent/day20"
+	_ "advent/day21"
+	_ "advent/day22"
+	_ "advent/day23"
+	_ "advent/day24"
+)
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"advent/aoc"
+	"advent/util"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+)
+
+// subcommands maps the name of each subcommand to the function running it with
+// the remaining arguments and the config. Each returns true if it succeeded.
+var subcommands = map[string]func(args []string, config Config) bool{
+	"fetch":  runFetch,
+	"new":    runNew,
+	"submit": runSubmit,
+}
+
+// runFetch downloads the input and first example for a day into the day's
+// files directory, as input.txt and test.txt. The input goes under the input
+// root instead, if there is one. Files that already exist are left alone,
+// unless they are empty, as new leaves them.
+func runFetch(args []string, config Config) bool {
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	year := flags.Int("y", config.Year, "year")
+	day := flags.Int("d", -1, "day number")
+	baseURL := flags.String("url", aoc.DefaultBaseURL, "address of the Advent of Code website")
+	sessionFile := flags.String("session", defaultSessionFile(), "file holding the session token, if "+aoc.SessionEnvVar+" is not set")
+	cacheDir := flags.String("cache", config.CacheDir, "directory to cache downloads in")
+	interval := flags.Duration("interval", aoc.DefaultMinInterval, "least time to leave between requests")
+	inputRoot := flags.String("inputs", config.InputRoot, "directory to write the input under, in place of the module root")
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	if *day <= 0 {
+		fmt.Println("Day number must be greater than 0")
+		return false
+	}
+	// the session token is only read once an uncached input is needed, since
+	// examples are public
+	client := aoc.NewClient(*baseURL, "", *cacheDir)
+	client.MinInterval = *interval
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	info := util.DayInfo{Year: *year, Day: *day}
+	downloads := []struct {
+		name         string
+		needsSession bool
+		download     func(context.Context, int, int) ([]byte, error)
+	}{
+		{InputFileName, true, client.Input},
+		{TestFileName, false, client.Example},
+	}
+	ok := true
+	for _, d := range downloads {
+		path := getNamedFilepath(*inputRoot, info, d.name)
+		if exists, err := keepExisting(path); err != nil {
+			fmt.Printf("Error fetching %s: %s\n", path, err)
+			ok = false
+			continue
+		} else if exists {
+			continue
+		}
+		if d.needsSession && client.Session == "" && !client.IsCached(*year, *day) {
+			session, err := aoc.ReadSession(*sessionFile)
+			if err != nil {
+				fmt.Printf("Error reading session token: %s\n", err)
+				ok = false
+				continue
+			}
+			client.Session = session
+		}
+		if err := fetchFile(ctx, path, func(ctx context.Context) ([]byte, error) {
+			return d.download(ctx, *year, *day)
+		}); err != nil {
+			fmt.Printf("Error fetching %s: %s\n", path, err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// fetchFile writes the result of download to path.
+func fetchFile(ctx context.Context, path string, download func(context.Context) ([]byte, error)) error {
+	data, err := download(ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
+
+// defaultSessionFile returns the path to the session token file in the user's
+// config directory, or "" if there is none.
+func defaultSessionFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "advent", "session")
+}
+
+// defaultCacheDir returns the path to the download cache in the user's cache
+// directory, or a directory in the working directory if there is none.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ".advent_cache"
+	}
+	return filepath.Join(dir, "advent")
+}
+
+// keepExisting returns true, and says it is leaving it alone, if there is
+// already a file with something in it at path. An empty file counts as
+// missing, so that the empty input files made by new are filled in.
+func keepExisting(path string) (bool, error) {
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if stat.Size() == 0 {
+		return false, nil
+	}
+	fmt.Printf("%s already exists, leaving it alone\n", path)
+	return true, nil
+}
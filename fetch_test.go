@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeepExisting(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{"empty.txt": "", "full.txt": "1\n"}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, want := range map[string]bool{"missing.txt": false, "empty.txt": false, "full.txt": true} {
+		if got, err := keepExisting(filepath.Join(root, name)); err != nil || got != want {
+			t.Errorf("keepExisting(%s) = %t, %v, want %t, nil", name, got, err, want)
+		}
+	}
+}
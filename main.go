@@ -1,218 +1,310 @@
 package main
 
 import (
-	"advent/day01"
-	"advent/day02"
-	"advent/day03"
-	"advent/day04"
-	"advent/day05"
-	"advent/day06"
-	"advent/day07"
-	"advent/day08"
-	"advent/day09"
-	"advent/day10"
-	"advent/day11"
-	"advent/day12"
-	"advent/day13"
-	"advent/day14"
-	"advent/day15"
-	"advent/day16"
-	"advent/day17"
-	"advent/day18"
-	"advent/day19"
-	"advent/day20"
-	"advent/day21"
-	"advent/day22"
-	"advent/day23"
-	"advent/day24"
 	"advent/util"
 	"flag"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
 )
 
-const FilePrefix = "day%s/files/%s.txt"
+// FilePrefix and AnswersFilePrefix are formatted with the directory of a day,
+// as given by util.DayDir.
+const FilePrefix = "%s/files/%s.txt"
+const AnswersFilePrefix = "%s/files/answers.json"
 const TestFileName = "test"
 const InputFileName = "input"
 
+// Flags holds the command line flags.
+type Flags struct {
+	Test bool
+	// Example is the name of an example input in the day's files directory,
+	// such as "test2".
+	Example string
+	// Input is the path to an input file anywhere, or "-" for stdin.
+	Input  string
+	Day    int
+	Year   int
+	Part   int
+	All    bool
+	Verify bool
+	// Format is the output format, TextFormat or JSONFormat.
+	Format  string
+	Timeout time.Duration
+	Verbose bool
+	// Workers is the number of phases -all runs at once.
+	Workers int
+	// Bench is the number of times to run each phase of a day when
+	// benchmarking, or 0 to run normally.
+	Bench     int
+	BenchFile string
+	// CPUProfile and AllocProfile are the paths to write pprof profiles to, if
+	// not empty. The allocation profile covers the whole process, not only the
+	// days run.
+	CPUProfile   string
+	AllocProfile string
+	// Watch reruns the day whenever its files change, checking every Poll.
+	Watch bool
+	Poll  time.Duration
+	// Params overrides the day's puzzle parameters.
+	Params util.Params
+	// InputRoot is the directory holding each day's real input, or "" for the
+	// module root.
+	InputRoot string
+}
+
 func main() {
-	testFlag, dayFlag := setUpFlags()
-	if *dayFlag <= 0 {
-		fmt.Println("Day number must be greater than 0")
-		return
+	config, err := readConfig(configPath())
+	if err != nil {
+		fmt.Printf("Error reading config: %s\n", err)
+		os.Exit(1)
 	}
-	filepath := getFilepath(*dayFlag, *testFlag)
-
-	factory, ok := SolutionFactories[*dayFlag]
-	if !ok {
-		fmt.Printf("No solution found for day %d\n", *dayFlag)
-		return
+	if len(os.Args) > 1 {
+		if subcommand, ok := subcommands[os.Args[1]]; ok {
+			if !subcommand(os.Args[2:], config) {
+				os.Exit(1)
+			}
+			return
+		}
 	}
-
-	solution, err := factory(filepath)
-	if err != nil {
-		fmt.Printf("Error creating solution: %s\n", err)
-		return
+	flags := setUpFlags(config)
+	if len(util.DaysInYear(flags.Year)) == 0 {
+		fmt.Printf("No solutions found for %d, years with solutions: %v\n", flags.Year, util.Years())
+		os.Exit(1)
 	}
-
-	answer, err := solution.PartOneAnswer()
-	if err != nil {
-		fmt.Printf("Error getting answer for part 1: %s\n", err)
-		return
+	if flags.Part < 0 || flags.Part > 2 {
+		fmt.Println("Part must be 1 or 2")
+		os.Exit(1)
 	}
-	fmt.Printf("Part 1 answer: %d\n", answer)
-
-	answer, err = solution.PartTwoAnswer()
+	if flags.Input != "" && (flags.All || flags.Verify) {
+		fmt.Println("An input path can only be given for a single day")
+		os.Exit(1)
+	}
+	if flags.Input != "" && (flags.Test || flags.Example != "") {
+		fmt.Println("An input path can't be given together with -t or -e")
+		os.Exit(1)
+	}
+	if flags.Format != TextFormat && flags.Format != JSONFormat {
+		fmt.Printf("Format must be %s or %s\n", TextFormat, JSONFormat)
+		os.Exit(1)
+	}
+	if flags.Format == JSONFormat && flags.Verify {
+		fmt.Println("Verification can only be written as text")
+		os.Exit(1)
+	}
+	if len(flags.Params) > 0 && (flags.All || flags.Day <= 0) {
+		fmt.Println("Parameters can only be given for a single day")
+		os.Exit(1)
+	}
+	if flags.Watch && (flags.All || flags.Verify || flags.Bench > 0 || flags.Input == "-" || flags.Format == JSONFormat) {
+		fmt.Println("Watch mode runs a single day from a file, written as text")
+		os.Exit(1)
+	}
+	// Results are written to out. For JSON, anything printed by the
+	// solutions themselves is sent to stderr, so it can't corrupt the records.
+	out := os.Stdout
+	if flags.Format == JSONFormat {
+		os.Stdout = os.Stderr
+	}
+	opts := RunOptions{
+		Part:      flags.Part,
+		Timeout:   flags.Timeout,
+		Logger:    util.NewLogger(os.Stderr, flags.Verbose),
+		DayParams: config.Params,
+		Params:    flags.Params,
+		InputRoot: flags.InputRoot,
+	}
+	stopProfiles, err := startProfiles(flags.CPUProfile, flags.AllocProfile)
 	if err != nil {
-		fmt.Printf("Error getting answer for part 2: %s\n", err)
-		return
+		fmt.Fprintf(os.Stderr, "Error starting profiles: %s\n", err)
+		os.Exit(1)
 	}
-	fmt.Printf("Part 2 answer: %d\n", answer)
-}
-
-// setUpTestFlag sets up the test flag and the day number, and returns them.
-func setUpFlags() (*bool, *int) {
-	testFlag := flag.Bool("t", false, "run with test.txt")
-	dayFlag := flag.Int("d", -1, "day number")
-	flag.Parse()
-	return testFlag, dayFlag
-}
-
-func getFilepath(day int, testFlag bool) string {
-	filename := InputFileName
-	if testFlag {
-		filename = TestFileName
+	ok := run(out, flags, opts)
+	if err := stopProfiles(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing profiles: %s\n", err)
+		ok = false
 	}
-	return fmt.Sprintf(FilePrefix, getTwoDigitNumber(day), filename)
-}
-
-func getTwoDigitNumber(n int) string {
-	if n < 10 {
-		return fmt.Sprintf("0%d", n)
+	if !ok {
+		os.Exit(1)
 	}
-	return fmt.Sprintf("%d", n)
-}
-
-type SolutionFactory func(string) (util.Solution, error)
-
-var SolutionFactories = map[int]SolutionFactory{
-	1:  Day01SolutionFactory,
-	2:  Day02SolutionFactory,
-	3:  Day03SolutionFactory,
-	4:  Day04SolutionFactory,
-	5:  Day05SolutionFactory,
-	6:  Day06SolutionFactory,
-	7:  Day07SolutionFactory,
-	8:  Day08SolutionFactory,
-	9:  Day09SolutionFactory,
-	10: Day10SolutionFactory,
-	11: Day11SolutionFactory,
-	12: Day12SolutionFactory,
-	13: Day13SolutionFactory,
-	14: Day14SolutionFactory,
-	15: Day15SolutionFactory,
-	16: Day16SolutionFactory,
-	17: Day17SolutionFactory,
-	18: Day18SolutionFactory,
-	19: Day19SolutionFactory,
-	20: Day20SolutionFactory,
-	21: Day21SolutionFactory,
-	22: Day22SolutionFactory,
-	23: Day23SolutionFactory,
-	24: Day24SolutionFactory,
-}
-
-func Day01SolutionFactory(filepath string) (util.Solution, error) {
-	return day01.NewDay01Solution(filepath)
-}
-
-func Day02SolutionFactory(filepath string) (util.Solution, error) {
-	return day02.NewDay02Solution(filepath)
-}
-
-func Day03SolutionFactory(filepath string) (util.Solution, error) {
-	return day03.NewDay03Solution(filepath)
-}
-
-func Day04SolutionFactory(filepath string) (util.Solution, error) {
-	return day04.NewDay04Solution(filepath)
-}
-
-func Day05SolutionFactory(filepath string) (util.Solution, error) {
-	return day05.NewDay05Solution(filepath)
-}
-
-func Day06SolutionFactory(filepath string) (util.Solution, error) {
-	return day06.NewDay06Solution(filepath)
-}
-
-func Day07SolutionFactory(filepath string) (util.Solution, error) {
-	return day07.NewDay07Solution(filepath)
-}
-
-func Day08SolutionFactory(filepath string) (util.Solution, error) {
-	return day08.NewDay08Solution(filepath)
-}
-
-func Day09SolutionFactory(filepath string) (util.Solution, error) {
-	return day09.NewDay09Solution(filepath)
-}
-
-func Day10SolutionFactory(filepath string) (util.Solution, error) {
-	return day10.NewDay10Solution(filepath)
 }
 
-func Day11SolutionFactory(filepath string) (util.Solution, error) {
-	return day11.NewDay11Solution(filepath)
-}
-
-func Day12SolutionFactory(filepath string) (util.Solution, error) {
-	return day12.NewDay12Solution(filepath)
-}
-
-func Day13SolutionFactory(filepath string) (util.Solution, error) {
-	return day13.NewDay13Solution(filepath)
-}
-
-func Day14SolutionFactory(filepath string) (util.Solution, error) {
-	return day14.NewDay14Solution(filepath)
-}
-
-func Day15SolutionFactory(filepath string) (util.Solution, error) {
-	return day15.NewDay15Solution(filepath)
+// run runs the days chosen by flags in the mode chosen by flags, and writes
+// the results to out. It returns true if everything run succeeded.
+func run(out io.Writer, flags Flags, opts RunOptions) bool {
+	if flags.Verify {
+		return runVerify(flags.Year, flags.Day, opts)
+	}
+	if !flags.All && flags.Day <= 0 {
+		fmt.Println("Day number must be greater than 0")
+		return false
+	}
+	if flags.Watch {
+		return runWatch(out, flags)
+	}
+	if flags.Bench > 0 {
+		return runBenchmarks(out, flags, opts)
+	}
+	if flags.All {
+		return runAllDays(out, flags.Format, flags.Year, inputName(flags), flags.Workers, opts)
+	}
+	return runSingleDay(out, flags, opts)
 }
 
-func Day16SolutionFactory(filepath string) (util.Solution, error) {
-	return day16.NewDay16Solution(filepath)
+// runSingleDay runs the day chosen by flags, and writes the results to out. It
+// returns true if the day succeeded.
+func runSingleDay(out io.Writer, flags Flags, opts RunOptions) bool {
+	info, ok := util.LookupDay(flags.Year, flags.Day)
+	if !ok {
+		fmt.Printf("No solution found for day %d of %d\n", flags.Day, flags.Year)
+		return false
+	}
+	filepath, err := inputFilepath(info, flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input from stdin: %s\n", err)
+		return false
+	}
+	result := runDay(info, filepath, opts)
+	if flags.Input == "-" {
+		os.Remove(filepath)
+		result.Input = flags.Input
+	}
+	if flags.Format == JSONFormat {
+		if err := writeJSONRecords(out, []DayResult{result}, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing results: %s\n", err)
+			return false
+		}
+	} else {
+		printDayResult(out, result)
+	}
+	return !result.Failed()
 }
 
-func Day17SolutionFactory(filepath string) (util.Solution, error) {
-	return day17.NewDay17Solution(filepath)
+// printDayResult writes the answers and times taken for a single day to w.
+func printDayResult(w io.Writer, result DayResult) {
+	info := result.Info
+	fmt.Fprintf(w, "Day %d: %s (%s)\n", info.Day, info.Title, info.URL())
+	if result.SetupErr != nil {
+		fmt.Fprintf(w, "Error creating solution: %s\n", result.SetupErr)
+		return
+	}
+	fmt.Fprintf(w, "Parsed in %s\n", formatDuration(result.ParseTime))
+	if result.PrepareErr != nil {
+		fmt.Fprintf(w, "Error preparing solution: %s\n", result.PrepareErr)
+	} else if result.Prepared {
+		fmt.Fprintf(w, "Prepared in %s\n", formatDuration(result.PrepareTime))
+	}
+	for i := range result.Ran {
+		if !result.Ran[i] {
+			continue
+		}
+		if result.Errors[i] != nil {
+			fmt.Fprintf(w, "Error getting answer for part %d: %s\n", i+1, result.Errors[i])
+			continue
+		}
+		fmt.Fprintf(w, "Part %d answer: %s (%s)\n", i+1, result.Answers[i], formatDuration(result.PartTimes[i]))
+	}
 }
 
-func Day18SolutionFactory(filepath string) (util.Solution, error) {
-	return day18.NewDay18Solution(filepath)
+// setUpFlags sets up the command line flags, defaulting to the values in
+// config, and returns them once parsed.
+func setUpFlags(config Config) Flags {
+	var flags Flags
+	flag.BoolVar(&flags.Test, "t", false, "run with test.txt")
+	flag.StringVar(&flags.Example, "e", "", "run with the named example from the day's files, such as test2")
+	flag.StringVar(&flags.Input, "i", "", "run with the input file at this path, or - to read from stdin")
+	flag.IntVar(&flags.Day, "d", -1, "day number")
+	flag.IntVar(&flags.Year, "y", config.Year, "year")
+	flag.IntVar(&flags.Part, "p", 0, "run only part 1 or 2")
+	flag.BoolVar(&flags.All, "all", false, "run every day and print a summary table")
+	flag.BoolVar(&flags.Verify, "verify", false, "check answers against each day's answers.json")
+	flag.StringVar(&flags.Format, "format", config.Format, "output format, text or json")
+	flag.BoolVar(&flags.Verbose, "v", false, "log the solutions' debug traces to stderr")
+	flag.IntVar(&flags.Workers, "workers", config.Workers, "number of parts to run at once with -all")
+	flag.IntVar(&flags.Bench, "bench", 0, "run each phase of the day this many times, and report how long they took")
+	flag.StringVar(&flags.BenchFile, "benchfile", "bench_history.json", "file to save benchmark results to, and compare against")
+	flag.StringVar(&flags.CPUProfile, "cpuprofile", "", "write a CPU profile of the run to this file")
+	flag.StringVar(&flags.AllocProfile, "allocprofile", "", "write a profile of every allocation the whole process made, including the runner's own setup, to this file")
+	flag.Var(&flags.Params, "param", "set one of the day's puzzle parameters, such as width=11; can be repeated")
+	flag.BoolVar(&flags.Watch, "watch", false, "rebuild and rerun the day whenever its files change")
+	flag.DurationVar(&flags.Poll, "poll", DefaultPollInterval, "how often -watch checks for changes")
+	flag.DurationVar(&flags.Timeout, "timeout", config.timeout, "stop each part after this long, such as 30s; 0 for no limit")
+	flag.StringVar(&flags.InputRoot, "inputs", config.InputRoot, "directory holding each day's real input, such as DIR/day01/files/input.txt")
+	flag.Parse()
+	// the config's format is only a default for the modes that can write JSON
+	if (flags.Verify || flags.Watch) && !flagGiven("format") {
+		flags.Format = TextFormat
+	}
+	return flags
 }
 
-func Day19SolutionFactory(filepath string) (util.Solution, error) {
-	return day19.NewDay19Solution(filepath)
+// flagGiven returns true if the named flag was given on the command line.
+func flagGiven(name string) bool {
+	given := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			given = true
+		}
+	})
+	return given
 }
 
-func Day20SolutionFactory(filepath string) (util.Solution, error) {
-	return day20.NewDay20Solution(filepath)
+// inputName returns the name of the input file in a day's files directory
+// chosen by flags.
+func inputName(flags Flags) string {
+	if flags.Example != "" {
+		return flags.Example
+	}
+	if flags.Test {
+		return TestFileName
+	}
+	return InputFileName
 }
 
-func Day21SolutionFactory(filepath string) (util.Solution, error) {
-	return day21.NewDay21Solution(filepath)
+// getNamedFilepath returns the path to the day's input file with the given
+// name, such as "test" or "input". The real input is found under inputRoot, if
+// it is not empty, while the examples always live in the module.
+func getNamedFilepath(inputRoot string, info util.DayInfo, name string) string {
+	path := fmt.Sprintf(FilePrefix, info.Dir(), name)
+	if name == InputFileName && inputRoot != "" {
+		return filepath.Join(inputRoot, path)
+	}
+	return path
 }
 
-func Day22SolutionFactory(filepath string) (util.Solution, error) {
-	return day22.NewDay22Solution(filepath)
+// getAnswersFilepath returns the path to the day's expected answers file.
+func getAnswersFilepath(info util.DayInfo) string {
+	return fmt.Sprintf(AnswersFilePrefix, info.Dir())
 }
 
-func Day23SolutionFactory(filepath string) (util.Solution, error) {
-	return day23.NewDay23Solution(filepath)
+// inputFilepath returns the path to the input file for the day chosen by
+// flags. If the input is read from stdin, it is first copied to a temporary
+// file, which the caller must remove.
+func inputFilepath(info util.DayInfo, flags Flags) (string, error) {
+	switch flags.Input {
+	case "":
+		return getNamedFilepath(flags.InputRoot, info, inputName(flags)), nil
+	case "-":
+		return copyStdinToFile()
+	default:
+		return flags.Input, nil
+	}
 }
 
-func Day24SolutionFactory(filepath string) (util.Solution, error) {
-	return day24.NewDay24Solution(filepath)
+// copyStdinToFile copies all of stdin to a temporary file, and returns its
+// path. Solutions read their input by path, sometimes more than once, so stdin
+// cannot be handed to them directly. The caller must remove the file.
+func copyStdinToFile() (string, error) {
+	file, err := os.CreateTemp("", "advent-input-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, os.Stdin); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
 }
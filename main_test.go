@@ -0,0 +1,127 @@
+package main
+
+import (
+	"advent/util"
+	"context"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSolutions_Examples(t *testing.T) {
+	for _, info := range util.Days() {
+		t.Run(info.Dir(), func(t *testing.T) {
+			expected, err := util.ReadExpectedAnswers(getAnswersFilepath(info))
+			if err != nil {
+				t.Fatalf("ReadExpectedAnswers() error = %v", err)
+			}
+			for _, input := range slices.Sorted(maps.Keys(expected)) {
+				if !strings.HasPrefix(input, TestFileName) {
+					continue
+				}
+				t.Run(input, func(t *testing.T) {
+					testExample(t, info, expected, input)
+				})
+			}
+		})
+	}
+}
+
+// TestSolutions_ConcurrentParts runs both parts of each day at once on the
+// same solution, as -all does, so that shared state is caught by go test -race.
+func TestSolutions_ConcurrentParts(t *testing.T) {
+	opts := RunOptions{Logger: util.NewLogger(io.Discard, false), pool: newWorkerPool(2)}
+	for _, info := range util.Days() {
+		t.Run(info.Dir(), func(t *testing.T) {
+			expected, err := util.ReadExpectedAnswers(getAnswersFilepath(info))
+			if err != nil {
+				t.Fatalf("ReadExpectedAnswers() error = %v", err)
+			}
+			opts, ok := answeredParts(opts, expected, TestFileName)
+			if !ok {
+				t.Skip("no answers are known for the example")
+			}
+			result := runDay(info, getNamedFilepath("", info, TestFileName), opts)
+			if result.SetupErr != nil {
+				t.Fatalf("NewSolution() error = %v", result.SetupErr)
+			}
+			if result.PrepareErr != nil {
+				t.Errorf("Prepare() error = %v", result.PrepareErr)
+			}
+			for i := range result.Ran {
+				want, ok := expected.Get(TestFileName, i+1)
+				if !ok {
+					continue
+				}
+				if result.Errors[i] != nil {
+					t.Errorf("part %d error = %v", i+1, result.Errors[i])
+				} else if got := result.Answers[i].String(); got != want {
+					t.Errorf("part %d = %s, want %s", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+// testExample runs the day against the named example input, and checks each
+// part with an expected answer.
+func testExample(t *testing.T, info util.DayInfo, expected util.ExpectedAnswers, input string) {
+	filepath := getNamedFilepath("", info, input)
+	config, err := solutionConfig(info, filepath, RunOptions{Logger: util.NewLogger(io.Discard, false)})
+	if err != nil {
+		t.Fatalf("solutionConfig() error = %v", err)
+	}
+	solution, err := info.NewSolution(filepath, config)
+	if err != nil {
+		t.Fatalf("NewSolution() error = %v", err)
+	}
+	parts := []struct {
+		name   string
+		answer func(context.Context) (util.Answer, error)
+	}{
+		{"PartOneAnswer", solution.PartOneAnswer},
+		{"PartTwoAnswer", solution.PartTwoAnswer},
+	}
+	for i, part := range parts {
+		// parts without a known answer may not even terminate on the example
+		want, ok := expected.Get(input, i+1)
+		if !ok {
+			continue
+		}
+		got, err := part.answer(context.Background())
+		if err != nil {
+			t.Errorf("%s() error = %v", part.name, err)
+		} else if got.String() != want {
+			t.Errorf("%s() = %s, want %s", part.name, got, want)
+		}
+	}
+}
+
+func TestAnsweredParts(t *testing.T) {
+	expected := util.ExpectedAnswers{
+		"test":  {1: "12"},
+		"test2": {1: "3", 2: "4"},
+		"test3": {},
+	}
+	tests := []struct {
+		input    string
+		part     int
+		wantPart int
+		wantOk   bool
+	}{
+		{"test", 0, 1, true},
+		{"test", 2, 2, false},
+		{"test2", 0, 0, true},
+		{"test2", 2, 2, true},
+		{"test3", 0, 0, false},
+		{"missing", 0, 0, false},
+	}
+	for _, tt := range tests {
+		opts, ok := answeredParts(RunOptions{Part: tt.part}, expected, tt.input)
+		if ok != tt.wantOk || (ok && opts.Part != tt.wantPart) {
+			t.Errorf("answeredParts(part %d, %s) = part %d, %t, want part %d, %t", tt.part, tt.input, opts.Part, ok, tt.wantPart, tt.wantOk)
+		}
+	}
+}
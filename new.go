@@ -0,0 +1,243 @@
+package main
+
+import (
+	"advent/util"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// DaysFile is the file importing every day's package, so that each day is
+// registered.
+const DaysFile = "days.go"
+
+// scaffold holds what the generated files for a day are filled in with.
+type scaffold struct {
+	Year    int
+	Day     int
+	Title   string
+	Package string
+	Type    string
+}
+
+var solutionTemplate = template.Must(template.New("solution").Parse(`// Advent of Code, {{.Year}}, Day {{.Day}}
+//
+// https://adventofcode.com/{{.Year}}/day/{{.Day}}
+package {{.Package}}
+
+import (
+	"advent/util"
+	"context"
+	"errors"
+)
+
+type {{.Type}} struct {
+}
+
+func init() {
+	util.RegisterDay(util.DayInfo{
+		Year:  {{.Year}},
+		Day:   {{.Day}},
+		Title: {{printf "%q" .Title}},
+		NewSolution: func(filepath string, config util.SolutionConfig) (util.Solution, error) {
+			return New{{.Type}}(filepath)
+		},
+	})
+}
+
+func New{{.Type}}(filepath string) (*{{.Type}}, error) {
+	return &{{.Type}}{}, nil
+}
+
+func (s *{{.Type}}) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	return util.Answer{}, errors.New("not implemented")
+}
+
+func (s *{{.Type}}) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	return util.Answer{}, errors.New("not implemented")
+}
+`))
+
+var solutionTestTemplate = template.Must(template.New("solution_test").Parse(`package {{.Package}}
+
+import (
+	"advent/util"
+	"context"
+	"testing"
+)
+
+func Test{{.Type}}_Example(t *testing.T) {
+	solution, err := New{{.Type}}("files/test.txt")
+	if err != nil {
+		t.Fatalf("New{{.Type}}() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		answer func(context.Context) (util.Answer, error)
+		want   string
+	}{
+		{"PartOneAnswer", solution.PartOneAnswer, ""},
+		{"PartTwoAnswer", solution.PartTwoAnswer, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want == "" {
+				t.Skip("no expected answer yet")
+			}
+			got, err := tt.answer(context.Background())
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("%s() = %s, want %s", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+`))
+
+// emptyAnswers is the answers.json of a new day, with no answers yet.
+const emptyAnswers = `{
+	"input": {},
+	"test": {}
+}
+`
+
+// runNew creates the package for a new day, with a solution and test skeleton,
+// empty input files and an empty answers.json, and imports it in days.go.
+// Files that already exist are left alone.
+func runNew(args []string, config Config) bool {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	year := flags.Int("y", config.Year, "year")
+	day := flags.Int("d", -1, "day number")
+	title := flags.String("title", "", "title of the day's puzzle")
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	if *day <= 0 {
+		fmt.Println("Day number must be greater than 0")
+		return false
+	}
+	if err := scaffoldDay(".", *year, *day, *title); err != nil {
+		fmt.Printf("Error creating day %d: %s\n", *day, err)
+		return false
+	}
+	return true
+}
+
+// scaffoldDay creates the files for a new day in the module at root, and adds
+// the day's package to the module's days.go.
+func scaffoldDay(root string, year, day int, title string) error {
+	data := scaffold{
+		Year:    year,
+		Day:     day,
+		Title:   title,
+		Package: fmt.Sprintf("day%02d", day),
+		Type:    fmt.Sprintf("Day%02dSolution", day),
+	}
+	dir := util.DayDir(year, day)
+	solution, err := executeGoTemplate(solutionTemplate, data)
+	if err != nil {
+		return err
+	}
+	solutionTest, err := executeGoTemplate(solutionTestTemplate, data)
+	if err != nil {
+		return err
+	}
+	files := []struct {
+		path string
+		data []byte
+	}{
+		{filepath.Join(dir, "solution.go"), solution},
+		{filepath.Join(dir, "solution_test.go"), solutionTest},
+		{fmt.Sprintf(FilePrefix, dir, InputFileName), nil},
+		{fmt.Sprintf(FilePrefix, dir, TestFileName), nil},
+		{fmt.Sprintf(AnswersFilePrefix, dir), []byte(emptyAnswers)},
+	}
+	for _, file := range files {
+		if err := createFile(filepath.Join(root, file.path), file.data); err != nil {
+			return err
+		}
+	}
+	return registerDayPackage(filepath.Join(root, DaysFile), "advent/"+dir)
+}
+
+// executeGoTemplate returns the Go source made by executing tmpl with data,
+// formatted with gofmt.
+func executeGoTemplate(tmpl *template.Template, data scaffold) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// createFile writes data to a new file at path, unless there is already a file
+// there. The file is created exclusively, so one made in the meantime by
+// something else is never overwritten either.
+func createFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		fmt.Printf("%s already exists, leaving it alone\n", path)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// don't leave a half written file, which would be left alone next time
+		os.Remove(path)
+		return err
+	}
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
+
+// registerDayPackage adds a blank import of pkg to the import block of the
+// days file at path, keeping the imports sorted. Nothing is changed if pkg is
+// already imported.
+func registerDayPackage(path, pkg string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	source := string(data)
+	start := strings.Index(source, "import (\n")
+	if start < 0 {
+		return fmt.Errorf("%s has no import block", path)
+	}
+	start += len("import (\n")
+	end := strings.Index(source[start:], ")")
+	if end < 0 {
+		return fmt.Errorf("%s has an unterminated import block", path)
+	}
+	end += start
+	imports := strings.Split(strings.TrimSuffix(source[start:end], "\n"), "\n")
+	line := fmt.Sprintf("\t_ %q", pkg)
+	if slices.Contains(imports, line) {
+		fmt.Printf("%s already imports %s\n", path, pkg)
+		return nil
+	}
+	imports = append(imports, line)
+	slices.Sort(imports)
+	source = source[:start] + strings.Join(imports, "\n") + "\n" + source[end:]
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Added %s to %s\n", pkg, path)
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDaysFile = `package main
+
+import (
+	_ "advent/day01"
+	_ "advent/day03"
+)
+`
+
+func TestScaffoldDay(t *testing.T) {
+	root := t.TempDir()
+	daysPath := filepath.Join(root, DaysFile)
+	if err := os.WriteFile(daysPath, []byte(testDaysFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := scaffoldDay(root, 2024, 2, "Red-Nosed Reports"); err != nil {
+		t.Fatalf("scaffoldDay() error = %v", err)
+	}
+	for _, name := range []string{"solution.go", "solution_test.go"} {
+		path := filepath.Join(root, "day02", name)
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			t.Fatalf("%s does not parse: %v", name, err)
+		}
+		if file.Name.Name != "day02" {
+			t.Errorf("%s is in package %s, want day02", name, file.Name.Name)
+		}
+	}
+	for _, name := range []string{"input.txt", "test.txt", "answers.json"} {
+		if _, err := os.Stat(filepath.Join(root, "day02", "files", name)); err != nil {
+			t.Errorf("files/%s was not created: %v", name, err)
+		}
+	}
+	want := strings.Replace(testDaysFile, "\t_ \"advent/day03\"", "\t_ \"advent/day02\"\n\t_ \"advent/day03\"", 1)
+	if got, _ := os.ReadFile(daysPath); string(got) != want {
+		t.Errorf("days.go = %q, want %q", got, want)
+	}
+
+	// a second run leaves everything alone, even files that are empty
+	solutionPath := filepath.Join(root, "day02", "solution.go")
+	if err := os.WriteFile(solutionPath, []byte("package day02\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	answersPath := filepath.Join(root, "day02", "files", "answers.json")
+	if err := os.WriteFile(answersPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := scaffoldDay(root, 2024, 2, "Red-Nosed Reports"); err != nil {
+		t.Fatalf("scaffoldDay() error = %v", err)
+	}
+	if got, _ := os.ReadFile(solutionPath); string(got) != "package day02\n" {
+		t.Errorf("solution.go was overwritten with %q", got)
+	}
+	if got, _ := os.ReadFile(answersPath); len(got) != 0 {
+		t.Errorf("empty answers.json was overwritten with %q", got)
+	}
+	if got, _ := os.ReadFile(daysPath); string(got) != want {
+		t.Errorf("days.go = %q after a second run, want %q", got, want)
+	}
+}
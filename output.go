@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// The output formats accepted by -format.
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// PartRecord is the JSON record written for one part of one day. Times are in
+// nanoseconds.
+type PartRecord struct {
+	Year      int    `json:"year"`
+	Day       int    `json:"day"`
+	Title     string `json:"title"`
+	Input     string `json:"input"`
+	Part      int    `json:"part"`
+	Answer    string `json:"answer,omitempty"`
+	Error     string `json:"error,omitempty"`
+	ParseTime int64  `json:"parse_ns"`
+	PartTime  int64  `json:"part_ns"`
+	// PrepareTime and PrepareError are only set for a solution with work
+	// shared by both parts. Each part's record repeats them.
+	PrepareTime  int64  `json:"prepare_ns,omitempty"`
+	PrepareError string `json:"prepare_error,omitempty"`
+}
+
+// partRecords returns a record for every part run in result. If the solution
+// could not be created, every part opts would have run gets a record holding
+// that error.
+func partRecords(result DayResult, opts RunOptions) []PartRecord {
+	records := make([]PartRecord, 0, len(result.Ran))
+	for i := range result.Ran {
+		if !opts.runsPart(i + 1) {
+			continue
+		}
+		record := PartRecord{
+			Year:      result.Info.Year,
+			Day:       result.Info.Day,
+			Title:     result.Info.Title,
+			Input:     result.Input,
+			Part:      i + 1,
+			ParseTime: result.ParseTime.Nanoseconds(),
+			PartTime:  result.PartTimes[i].Nanoseconds(),
+		}
+		if result.Prepared {
+			record.PrepareTime = result.PrepareTime.Nanoseconds()
+		}
+		if result.PrepareErr != nil {
+			record.PrepareError = result.PrepareErr.Error()
+		}
+		if result.SetupErr != nil {
+			record.Error = result.SetupErr.Error()
+		} else if result.Errors[i] != nil {
+			record.Error = result.Errors[i].Error()
+		} else {
+			record.Answer = result.Answers[i].String()
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// writeJSONRecords writes a record for every part run in results, as
+// controlled by opts, to w, one JSON object per line.
+func writeJSONRecords(w io.Writer, results []DayResult, opts RunOptions) error {
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		for _, record := range partRecords(result, opts) {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
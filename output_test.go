@@ -0,0 +1,97 @@
+package main
+
+import (
+	"advent/util"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWriteJSONRecords(t *testing.T) {
+	info := util.DayInfo{Year: 2024, Day: 3, Title: "Mull It Over"}
+	results := []DayResult{
+		{
+			Info:      info,
+			Input:     "day03/files/test.txt",
+			Ran:       [2]bool{true, true},
+			Answers:   [2]util.Answer{util.NewIntAnswer(161)},
+			Errors:    [2]error{nil, errors.New("no answer")},
+			ParseTime: time.Millisecond,
+			PartTimes: [2]time.Duration{2 * time.Millisecond, 3 * time.Millisecond},
+		},
+		{
+			Info:     info,
+			Input:    "missing.txt",
+			SetupErr: errors.New("no such file"),
+		},
+	}
+	want := []PartRecord{
+		{Year: 2024, Day: 3, Title: "Mull It Over", Input: "day03/files/test.txt", Part: 1, Answer: "161",
+			ParseTime: 1e6, PartTime: 2e6},
+		{Year: 2024, Day: 3, Title: "Mull It Over", Input: "day03/files/test.txt", Part: 2, Error: "no answer",
+			ParseTime: 1e6, PartTime: 3e6},
+		{Year: 2024, Day: 3, Title: "Mull It Over", Input: "missing.txt", Part: 1, Error: "no such file"},
+		{Year: 2024, Day: 3, Title: "Mull It Over", Input: "missing.txt", Part: 2, Error: "no such file"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONRecords(&buf, results, RunOptions{}); err != nil {
+		t.Fatalf("writeJSONRecords() error = %v", err)
+	}
+	decoder := json.NewDecoder(&buf)
+	for i, w := range want {
+		var got PartRecord
+		if err := decoder.Decode(&got); err != nil {
+			t.Fatalf("record %d: Decode() error = %v", i, err)
+		}
+		if got != w {
+			t.Errorf("record %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if decoder.More() {
+		t.Errorf("writeJSONRecords() wrote more than %d records", len(want))
+	}
+}
+
+func TestWriteJSONRecords_OnePart(t *testing.T) {
+	result := DayResult{
+		Info:    util.DayInfo{Year: 2024, Day: 1},
+		Ran:     [2]bool{false, true},
+		Answers: [2]util.Answer{{}, util.NewIntAnswer(31)},
+	}
+	var buf bytes.Buffer
+	if err := writeJSONRecords(&buf, []DayResult{result}, RunOptions{Part: 2}); err != nil {
+		t.Fatalf("writeJSONRecords() error = %v", err)
+	}
+	var got PartRecord
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Part != 2 || got.Answer != "31" {
+		t.Errorf("writeJSONRecords() = %+v, want part 2 with answer 31", got)
+	}
+}
+
+func TestWriteJSONRecords_Prepared(t *testing.T) {
+	result := DayResult{
+		Info:        util.DayInfo{Year: 2024, Day: 16},
+		Ran:         [2]bool{true, false},
+		Answers:     [2]util.Answer{util.NewIntAnswer(7036)},
+		Prepared:    true,
+		PrepareErr:  errors.New("timed out"),
+		PrepareTime: time.Millisecond,
+	}
+	var buf bytes.Buffer
+	if err := writeJSONRecords(&buf, []DayResult{result}, RunOptions{Part: 1}); err != nil {
+		t.Fatalf("writeJSONRecords() error = %v", err)
+	}
+	var got PartRecord
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.PrepareTime != 1e6 || got.PrepareError != "timed out" || got.Answer != "7036" {
+		t.Errorf("writeJSONRecords() = %+v, want prepare_ns 1e6, its error, and answer 7036", got)
+	}
+}
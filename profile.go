@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiles starts a CPU profile written to cpuPath, and prepares an
+// allocation profile written to allocPath. Either path may be empty to skip
+// that profile. The returned function stops the CPU profile and writes the
+// allocation profile, and must be called once the run is over.
+func startProfiles(cpuPath, allocPath string) (func() error, error) {
+	var cpuFile *os.File
+	if cpuPath != "" {
+		var err error
+		cpuFile, err = os.Create(cpuPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			cpuFile.Close()
+			return nil, err
+		}
+	}
+	return func() error {
+		var errs []error
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			errs = append(errs, cpuFile.Close())
+		}
+		if allocPath != "" {
+			errs = append(errs, writeAllocProfile(allocPath))
+		}
+		return errors.Join(errs...)
+	}, nil
+}
+
+// writeAllocProfile writes a profile of every allocation made so far to path.
+// Allocations are recorded from the start of the program, so the profile also
+// holds whatever the runner allocated itself, such as while reading its config
+// and flags. pprof labels don't apply to memory profiles, so unlike the CPU
+// profile it can't be split by day or phase.
+func writeAllocProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// collect garbage so that the in-use figures are up to date
+	runtime.GC()
+	return pprof.Lookup("allocs").WriteTo(file, 0)
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"advent/util"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// DayResult holds the outcome of running the parts of a single day.
+type DayResult struct {
+	Info util.DayInfo
+	// Input names the input the day was run against, usually its path.
+	Input string
+	// SetupErr is set if the solution could not be created, in which case
+	// neither part is run.
+	SetupErr error
+	// Ran records which parts were run. A part left out with -p has no answer,
+	// error or time.
+	Ran     [2]bool
+	Answers [2]util.Answer
+	Errors  [2]error
+	// ParseTime is the time taken to create the solution, and PartTimes the
+	// time taken by each part on its own.
+	ParseTime time.Duration
+	PartTimes [2]time.Duration
+	// Prepared records whether the solution has work shared by both parts,
+	// which is run as a phase of its own before either part. If it fails with
+	// PrepareErr, the parts are still run, and each does the work itself.
+	Prepared    bool
+	PrepareErr  error
+	PrepareTime time.Duration
+}
+
+// Failed returns true if the solution could not be created, or its shared
+// work or either part returned an error.
+func (r DayResult) Failed() bool {
+	return r.SetupErr != nil || r.PrepareErr != nil || r.Errors[0] != nil || r.Errors[1] != nil
+}
+
+// The phases of running a day, used to label benchmarks and profiles.
+const (
+	ParsePhase   = "parse"
+	PreparePhase = "prepare"
+	PartOnePhase = "part 1"
+	PartTwoPhase = "part 2"
+)
+
+// RunOptions controls how the parts of a day are run.
+type RunOptions struct {
+	// Part is the only part to run, or 0 to run both.
+	Part int
+	// Timeout is the time each part is given before it is stopped, or 0 for no
+	// limit.
+	Timeout time.Duration
+	// Logger is passed to the solution for its debug traces.
+	Logger *slog.Logger
+	// DayParams overrides the parameters of each day, keyed by the day's
+	// directory and then by input name, as given by the config file. Params
+	// overrides them again, as given by -param.
+	DayParams map[string]map[string]util.Params
+	Params    util.Params
+	// InputRoot, if not empty, is the directory holding each day's real
+	// input, in place of the module root.
+	InputRoot string
+	// pool, if set, runs each phase, and lets both parts of a day run at once.
+	pool workerPool
+}
+
+// workerPool bounds how many phases run at once. A nil pool runs every phase
+// straight away, on the calling goroutine.
+type workerPool chan struct{}
+
+func newWorkerPool(workers int) workerPool {
+	return make(workerPool, max(workers, 1))
+}
+
+// run runs f once a worker is free.
+func (p workerPool) run(f func()) {
+	if p == nil {
+		f()
+		return
+	}
+	p <- struct{}{}
+	defer func() { <-p }()
+	f()
+}
+
+// runsPart returns true if part i, counted from 1, should be run.
+func (o RunOptions) runsPart(i int) bool {
+	return o.Part == 0 || o.Part == i
+}
+
+// Duration returns the total time taken to create the solution and run its
+// phases.
+func (r DayResult) Duration() time.Duration {
+	return r.ParseTime + r.PrepareTime + r.PartTimes[0] + r.PartTimes[1]
+}
+
+// runAllDays runs every registered day of year on a pool of workers, and
+// writes the results to w in day order in the given format, as a summary table
+// for text. Each day is run against its input file with the given name, as
+// controlled by opts. A failing day does not stop the rest from running. It
+// returns true if every day succeeded.
+func runAllDays(w io.Writer, format string, year int, input string, workers int, opts RunOptions) bool {
+	days := util.DaysInYear(year)
+	results := make([]DayResult, len(days))
+	opts.pool = newWorkerPool(workers)
+	var wg sync.WaitGroup
+	for i, info := range days {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runDay(info, getNamedFilepath(opts.InputRoot, info, input), opts)
+		}()
+	}
+	wg.Wait()
+	if format == JSONFormat {
+		if err := writeJSONRecords(w, results, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing results: %s\n", err)
+			return false
+		}
+	} else {
+		printSummaryTable(w, results)
+	}
+	for _, result := range results {
+		if result.Failed() {
+			return false
+		}
+	}
+	return true
+}
+
+// runDay creates the day's solution and runs its parts as controlled by opts,
+// recording the answers, errors and the wall-clock time taken by parsing, by
+// any work the parts share, and by each part. Each phase is run with pprof
+// labels naming the day and phase, so that they can be told apart in a CPU
+// profile. With a worker pool in opts, both parts run at once, and each phase
+// is only timed once it has a worker.
+func runDay(info util.DayInfo, filepath string, opts RunOptions) DayResult {
+	result := DayResult{Info: info, Input: filepath}
+	var solution util.Solution
+	opts.pool.run(func() {
+		pprof.Do(context.Background(), phaseLabels(info.Year, info.Day, ParsePhase), func(context.Context) {
+			start := time.Now()
+			result.SetupErr = recoverError(func() error {
+				config, err := solutionConfig(info, filepath, opts)
+				if err != nil {
+					return err
+				}
+				solution, err = info.NewSolution(filepath, config)
+				return err
+			})
+			result.ParseTime = time.Since(start)
+		})
+	})
+	if result.SetupErr != nil {
+		return result
+	}
+	opts.pool.run(func() {
+		pprof.Do(context.Background(), phaseLabels(info.Year, info.Day, PreparePhase), func(ctx context.Context) {
+			start := time.Now()
+			result.Prepared, result.PrepareErr = prepare(ctx, solution, opts.Timeout)
+			result.PrepareTime = time.Since(start)
+		})
+	})
+	parts := []func(context.Context) (util.Answer, error){solution.PartOneAnswer, solution.PartTwoAnswer}
+	phases := []string{PartOnePhase, PartTwoPhase}
+	var wg sync.WaitGroup
+	for i, answer := range parts {
+		if !opts.runsPart(i + 1) {
+			continue
+		}
+		result.Ran[i] = true
+		runPhase := func() {
+			pprof.Do(context.Background(), phaseLabels(info.Year, info.Day, phases[i]), func(ctx context.Context) {
+				start := time.Now()
+				result.Errors[i] = runPart(ctx, answer, &result.Answers[i], opts.Timeout)
+				result.PartTimes[i] = time.Since(start)
+			})
+		}
+		if opts.pool == nil {
+			runPhase()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts.pool.run(runPhase)
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// solutionConfig returns the config to create the day's solution with for the
+// input file designated by filepath, with the parameters in opts overriding the
+// day's own. The config file's parameters are chosen for the input as the
+// day's own are, except that an example never gets those for the real input.
+func solutionConfig(info util.DayInfo, filepath string, opts RunOptions) (util.SolutionConfig, error) {
+	dayParams, _ := util.InputParams(opts.DayParams[info.Dir()], filepath)
+	overrides := maps.Clone(dayParams)
+	if overrides == nil {
+		overrides = make(util.Params)
+	}
+	maps.Copy(overrides, opts.Params)
+	params, err := info.ParamsFor(filepath, overrides)
+	return util.SolutionConfig{Logger: opts.Logger, Params: params}, err
+}
+
+// prepare runs the work shared by the solution's parts, if it has any, with
+// the given timeout, or none if timeout is 0. It returns false if the solution
+// has no shared work.
+func prepare(ctx context.Context, solution util.Solution, timeout time.Duration) (bool, error) {
+	preparer, ok := solution.(util.Preparer)
+	if !ok {
+		return false, nil
+	}
+	var none util.Answer
+	return true, runPart(ctx, func(ctx context.Context) (util.Answer, error) {
+		return none, preparer.Prepare(ctx)
+	}, &none, timeout)
+}
+
+// phaseLabels returns the pprof labels for a phase of the given day.
+func phaseLabels(year, day int, phase string) pprof.LabelSet {
+	return pprof.Labels("year", strconv.Itoa(year), "day", strconv.Itoa(day), "phase", phase)
+}
+
+// runPart runs answer with a context derived from ctx, with the given timeout,
+// or none if timeout is 0, and stores its answer in dest.
+func runPart(ctx context.Context, answer func(context.Context) (util.Answer, error), dest *util.Answer, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := recoverError(func() error {
+		var err error
+		*dest, err = answer(ctx)
+		return err
+	})
+	if errors.Is(err, util.ErrTimedOut) {
+		return fmt.Errorf("%w after %s", err, timeout)
+	}
+	return err
+}
+
+// recoverError runs f, and returns its error. If f panics, the panic is
+// returned as an error instead.
+func recoverError(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return f()
+}
+
+// printSummaryTable writes a table of results to w, one row per day, followed
+// by the full text of any errors.
+func printSummaryTable(w io.Writer, results []DayResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tTitle\tPart 1\tPart 2\tParse\tPrepare\tPart 1 time\tPart 2 time\tTotal")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", result.Info.Day, result.Info.Title,
+			result.cell(0), result.cell(1), formatDuration(result.ParseTime), result.prepareCell(),
+			result.timeCell(0), result.timeCell(1), formatDuration(result.Duration()))
+	}
+	tw.Flush()
+	for _, result := range results {
+		if result.SetupErr != nil {
+			fmt.Fprintf(w, "Day %d: error creating solution: %s\n", result.Info.Day, result.SetupErr)
+		}
+		if result.PrepareErr != nil {
+			fmt.Fprintf(w, "Day %d: error preparing solution: %s\n", result.Info.Day, result.PrepareErr)
+		}
+		for i, err := range result.Errors {
+			if err != nil {
+				fmt.Fprintf(w, "Day %d: error getting answer for part %d: %s\n", result.Info.Day, i+1, err)
+			}
+		}
+	}
+}
+
+// cell returns the text to show in the table for part i.
+func (r DayResult) cell(i int) string {
+	if r.SetupErr != nil || r.Errors[i] != nil {
+		return "ERROR"
+	}
+	if !r.Ran[i] {
+		return "-"
+	}
+	return r.Answers[i].String()
+}
+
+// timeCell returns the text to show in the table for the time taken by part i.
+func (r DayResult) timeCell(i int) string {
+	if !r.Ran[i] {
+		return "-"
+	}
+	return formatDuration(r.PartTimes[i])
+}
+
+// prepareCell returns the text to show in the table for the time taken by the
+// work shared by both parts.
+func (r DayResult) prepareCell() string {
+	if !r.Prepared {
+		return "-"
+	}
+	return formatDuration(r.PrepareTime)
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
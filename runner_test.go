@@ -0,0 +1,102 @@
+package main
+
+import (
+	"advent/util"
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_BoundsConcurrency(t *testing.T) {
+	pool := newWorkerPool(3)
+	var running, most atomic.Int32
+	var wg sync.WaitGroup
+	for range 12 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pool.run(func() {
+				n := running.Add(1)
+				for {
+					m := most.Load()
+					if n <= m || most.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				running.Add(-1)
+			})
+		}()
+	}
+	wg.Wait()
+	if got := most.Load(); got > 3 {
+		t.Errorf("workerPool ran %d at once, want at most 3", got)
+	}
+}
+
+func TestWorkerPool_Nil(t *testing.T) {
+	var pool workerPool
+	ran := false
+	pool.run(func() { ran = true })
+	if !ran {
+		t.Errorf("nil workerPool did not run f")
+	}
+}
+
+// preparedSolution answers each part with whether Prepare ran before it. If
+// err is set, Prepare fails with it instead.
+type preparedSolution struct {
+	prepared bool
+	err      error
+}
+
+func (s *preparedSolution) Prepare(ctx context.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.prepared = true
+	return nil
+}
+
+func (s *preparedSolution) PartOneAnswer(ctx context.Context) (util.Answer, error) {
+	return util.NewStringAnswer(strconv.FormatBool(s.prepared)), nil
+}
+
+func (s *preparedSolution) PartTwoAnswer(ctx context.Context) (util.Answer, error) {
+	return s.PartOneAnswer(ctx)
+}
+
+func TestRunDay_Prepare(t *testing.T) {
+	info := util.DayInfo{
+		NewSolution: func(string, util.SolutionConfig) (util.Solution, error) {
+			return &preparedSolution{}, nil
+		},
+	}
+	result := runDay(info, "", RunOptions{Part: 2})
+	if !result.Prepared || result.PrepareErr != nil {
+		t.Fatalf("runDay() prepared = %t, %v, want true, nil", result.Prepared, result.PrepareErr)
+	}
+	if got := result.Answers[1].String(); got != "true" {
+		t.Errorf("part 2 = %s, want true, as Prepare runs first", got)
+	}
+}
+
+func TestRunDay_PrepareFails(t *testing.T) {
+	info := util.DayInfo{
+		NewSolution: func(string, util.SolutionConfig) (util.Solution, error) {
+			return &preparedSolution{err: errors.New("no path")}, nil
+		},
+	}
+	result := runDay(info, "", RunOptions{})
+	if result.PrepareErr == nil || !result.Failed() {
+		t.Errorf("runDay() prepare error = %v, want an error failing the day", result.PrepareErr)
+	}
+	// the parts still run, so that each can do the shared work itself
+	if !result.Ran[0] || !result.Ran[1] {
+		t.Errorf("runDay() ran %v, want both parts", result.Ran)
+	}
+}
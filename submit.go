@@ -0,0 +1,126 @@
+package main
+
+import (
+	"advent/aoc"
+	"advent/util"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// DefaultSubmitHistoryFile is where every submitted answer is recorded.
+const DefaultSubmitHistoryFile = "submit_history.json"
+
+// runSubmit submits an answer for a part of a day, and prints the website's
+// verdict. With no -a, the answer is found by running the part against the
+// day's input. Every attempt is recorded in the history file, and answers the
+// history already rules out are refused without being sent.
+func runSubmit(args []string, config Config) bool {
+	flags := flag.NewFlagSet("submit", flag.ContinueOnError)
+	year := flags.Int("y", config.Year, "year")
+	day := flags.Int("d", -1, "day number")
+	part := flags.Int("p", 0, "part to submit, 1 or 2")
+	answer := flags.String("a", "", "answer to submit, instead of running the part against the day's input")
+	historyFile := flags.String("history", DefaultSubmitHistoryFile, "file recording every submitted answer")
+	timeout := flags.Duration("timeout", config.timeout, "stop the part after this long, such as 30s; 0 for no limit")
+	baseURL := flags.String("url", aoc.DefaultBaseURL, "address of the Advent of Code website")
+	sessionFile := flags.String("session", defaultSessionFile(), "file holding the session token, if "+aoc.SessionEnvVar+" is not set")
+	cacheDir := flags.String("cache", config.CacheDir, "directory to cache downloads in")
+	inputRoot := flags.String("inputs", config.InputRoot, "directory holding each day's real input, in place of the module root")
+	interval := flags.Duration("interval", aoc.DefaultMinInterval, "least time to leave between requests")
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	if *day <= 0 {
+		fmt.Println("Day number must be greater than 0")
+		return false
+	}
+	if *part != 1 && *part != 2 {
+		fmt.Println("Part must be 1 or 2")
+		return false
+	}
+	if *answer == "" {
+		var ok bool
+		*answer, ok = solvePart(*year, *day, *part, *inputRoot, *timeout, config)
+		if !ok {
+			return false
+		}
+	}
+
+	history, err := aoc.ReadHistory(*historyFile)
+	if err != nil {
+		fmt.Printf("Error reading submission history: %s\n", err)
+		return false
+	}
+	if err := history.Check(*year, *day, *part, *answer, time.Now()); err != nil {
+		fmt.Printf("Not submitting %s: %s\n", *answer, err)
+		return false
+	}
+	session, err := aoc.ReadSession(*sessionFile)
+	if err != nil {
+		fmt.Printf("Error reading session token: %s\n", err)
+		return false
+	}
+	client := aoc.NewClient(*baseURL, session, *cacheDir)
+	client.MinInterval = *interval
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Printf("Submitting %s for day %d part %d\n", *answer, *day, *part)
+	result, err := client.Submit(ctx, *year, *day, *part, *answer)
+	if err != nil {
+		fmt.Printf("Error submitting answer: %s\n", err)
+		return false
+	}
+	history = append(history, aoc.Attempt{
+		Year:    *year,
+		Day:     *day,
+		Part:    *part,
+		Answer:  *answer,
+		Verdict: result.Verdict,
+		Wait:    result.Wait,
+		Time:    time.Now(),
+	})
+	if err := history.Write(*historyFile); err != nil {
+		fmt.Printf("Error saving submission history: %s\n", err)
+	}
+	fmt.Printf("Verdict: %s\n", result.Verdict)
+	if result.Wait > 0 {
+		fmt.Printf("Wait %s before submitting again\n", result.Wait)
+	}
+	if result.Verdict == aoc.VerdictUnknown {
+		fmt.Println(result.Message)
+	}
+	return result.Verdict == aoc.VerdictRight
+}
+
+// solvePart runs the part of the day against the day's input, with the
+// parameters in config, as -d does, and returns its answer, printing any error
+// instead.
+func solvePart(year, day, part int, inputRoot string, timeout time.Duration, config Config) (string, bool) {
+	info, ok := util.LookupDay(year, day)
+	if !ok {
+		fmt.Printf("No solution found for day %d of %d\n", day, year)
+		return "", false
+	}
+	opts := RunOptions{
+		Part:      part,
+		Timeout:   timeout,
+		Logger:    util.NewLogger(os.Stderr, false),
+		DayParams: config.Params,
+		InputRoot: inputRoot,
+	}
+	result := runDay(info, getNamedFilepath(opts.InputRoot, info, InputFileName), opts)
+	if result.SetupErr != nil {
+		fmt.Printf("Error creating solution: %s\n", result.SetupErr)
+		return "", false
+	}
+	if err := result.Errors[part-1]; err != nil {
+		fmt.Printf("Error in part %d: %s\n", part, err)
+		return "", false
+	}
+	return result.Answers[part-1].String(), true
+}
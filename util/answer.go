@@ -0,0 +1,58 @@
+package util
+
+import "strconv"
+
+// AnswerKind designates what kind of value an Answer holds.
+type AnswerKind int
+
+const (
+	IntAnswerKind AnswerKind = iota
+	StringAnswerKind
+	VectorAnswerKind
+)
+
+// Answer is the answer to one part of a puzzle. Most answers are integers, but
+// some puzzles are answered with a string or a coordinate.
+type Answer struct {
+	kind   AnswerKind
+	intVal int
+	strVal string
+	vecVal Vector
+}
+
+func NewIntAnswer(n int) Answer {
+	return Answer{kind: IntAnswerKind, intVal: n}
+}
+
+func NewStringAnswer(s string) Answer {
+	return Answer{kind: StringAnswerKind, strVal: s}
+}
+
+// NewVectorAnswer returns an answer holding a coordinate. It is written in
+// the form X,Y.
+func NewVectorAnswer(v Vector) Answer {
+	return Answer{kind: VectorAnswerKind, vecVal: v}
+}
+
+// Kind returns the kind of value held by the answer.
+func (a Answer) Kind() AnswerKind {
+	return a.kind
+}
+
+// Int returns the integer held by the answer, and whether the answer holds an
+// integer at all.
+func (a Answer) Int() (int, bool) {
+	return a.intVal, a.kind == IntAnswerKind
+}
+
+// String returns the answer as it would be entered on the puzzle page.
+func (a Answer) String() string {
+	switch a.kind {
+	case StringAnswerKind:
+		return a.strVal
+	case VectorAnswerKind:
+		return a.vecVal.String()
+	default:
+		return strconv.Itoa(a.intVal)
+	}
+}
@@ -16,7 +16,7 @@ var (
 )
 
 // Directions are ordered in a clockwise manner starting from UpDirection.
-var SimpleDirections = []*Vector{UpDirection, RightDirection, DownDirection, LeftDirection}
-var AllDirections = []*Vector{UpDirection, UpRightDirection, RightDirection, DownRightDirection,
+var SimpleDirections = []Vector{UpDirection, RightDirection, DownDirection, LeftDirection}
+var AllDirections = []Vector{UpDirection, UpRightDirection, RightDirection, DownRightDirection,
 	DownDirection, DownLeftDirection, LeftDirection, UpLeftDirection}
-var VerticalDirections = []*Vector{UpDirection, DownDirection}
+var VerticalDirections = []Vector{UpDirection, DownDirection}
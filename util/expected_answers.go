@@ -0,0 +1,31 @@
+package util
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// ExpectedAnswers maps the name of an input file, such as "test" or "input",
+// to the known correct answer for each part. A part missing from the map has
+// no known answer for that input.
+type ExpectedAnswers map[string]map[int]string
+
+// ReadExpectedAnswers reads expected answers from a JSON file designated by
+// filepath, in the form {"test": {"1": "11", "2": "31"}}. Returns an error if
+// there is an error opening or parsing the file.
+func ReadExpectedAnswers(filepath string) (ExpectedAnswers, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+	answers := make(ExpectedAnswers)
+	err = json.Unmarshal(data, &answers)
+	return answers, err
+}
+
+// Get returns the expected answer for the part when run against the named
+// input, and whether there is one.
+func (e ExpectedAnswers) Get(input string, part int) (string, bool) {
+	answer, ok := e[input][part]
+	return answer, ok
+}
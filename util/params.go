@@ -0,0 +1,95 @@
+package util
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultParamsInput names the parameter set used for any input without a set
+// of its own.
+const DefaultParamsInput = "input"
+
+// ExampleParamsInput names the parameter set used for any example without a
+// set of its own, that is any input whose name starts with it, such as
+// "test2".
+const ExampleParamsInput = "test"
+
+// Params are the named values a puzzle is solved with besides its input, such
+// as the size of a grid. The examples often use smaller values than the real
+// input.
+type Params map[string]int
+
+// String returns the parameters as comma-separated assignments, sorted by name,
+// such as "height=7,width=11".
+func (p Params) String() string {
+	assignments := make([]string, 0, len(p))
+	for _, name := range slices.Sorted(maps.Keys(p)) {
+		assignments = append(assignments, fmt.Sprintf("%s=%d", name, p[name]))
+	}
+	return strings.Join(assignments, ",")
+}
+
+// Set parses an assignment such as "width=11", and adds it to the parameters.
+// This makes Params usable as a repeatable flag.
+func (p *Params) Set(assignment string) error {
+	name, value, ok := strings.Cut(assignment, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("%q is not of the form name=value", assignment)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("value of %s is not an integer: %q", name, value)
+	}
+	if *p == nil {
+		*p = make(Params)
+	}
+	(*p)[name] = n
+	return nil
+}
+
+// InputParams returns the set of parameters in sets, keyed by input name, for
+// the input file at path, and whether there is one. The set is chosen by the
+// file's name without its extension, such as "test". Other examples use the
+// set for ExampleParamsInput, and everything else the set for
+// DefaultParamsInput. An example never uses the set for DefaultParamsInput.
+func InputParams(sets map[string]Params, path string) (Params, bool) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if params, ok := sets[name]; ok {
+		return params, true
+	}
+	if strings.HasPrefix(name, ExampleParamsInput) {
+		params, ok := sets[ExampleParamsInput]
+		return params, ok
+	}
+	params, ok := sets[DefaultParamsInput]
+	return params, ok
+}
+
+// ParamsFor returns the parameters the day is solved with for the input file
+// at path, chosen from the day's sets by InputParams, with overrides applied
+// on top. A day's parameters are the same for every input unless it says
+// otherwise, so an example without a set falls back to the set for
+// DefaultParamsInput. Returns an error if an override names a parameter the
+// day doesn't have.
+func (d DayInfo) ParamsFor(path string, overrides Params) (Params, error) {
+	defaults, ok := InputParams(d.Params, path)
+	if !ok {
+		defaults = d.Params[DefaultParamsInput]
+	}
+	params := maps.Clone(defaults)
+	if params == nil {
+		params = make(Params)
+	}
+	for name, value := range overrides {
+		if _, ok := params[name]; !ok {
+			return nil, fmt.Errorf("day %d has no parameter %q, parameters are: %s",
+				d.Day, name, strings.Join(slices.Sorted(maps.Keys(params)), ", "))
+		}
+		params[name] = value
+	}
+	return params, nil
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParams_Set(t *testing.T) {
+	var params Params
+	for _, assignment := range []string{"width=11", "height=7", "width=12"} {
+		if err := params.Set(assignment); err != nil {
+			t.Fatalf("Set(%q) error = %v", assignment, err)
+		}
+	}
+	if got := params.String(); got != "height=7,width=12" {
+		t.Errorf("String() = %q, want %q", got, "height=7,width=12")
+	}
+	for _, assignment := range []string{"width", "=3", "width=eleven"} {
+		if err := params.Set(assignment); err == nil {
+			t.Errorf("Set(%q) error = nil, want an error", assignment)
+		}
+	}
+}
+
+func TestDayInfo_ParamsFor(t *testing.T) {
+	info := DayInfo{Day: 14, Params: map[string]Params{
+		"input": {"width": 101, "height": 103},
+		"test":  {"width": 11, "height": 7},
+	}}
+	tests := []struct {
+		name      string
+		path      string
+		overrides Params
+		want      Params
+		wantErr   bool
+	}{
+		{"input", "day14/files/input.txt", nil, Params{"width": 101, "height": 103}, false},
+		{"example", "day14/files/test.txt", nil, Params{"width": 11, "height": 7}, false},
+		{"other example", "day14/files/test2.txt", nil, Params{"width": 11, "height": 7}, false},
+		{"example elsewhere", "/tmp/test_robots.txt", nil, Params{"width": 11, "height": 7}, false},
+		{"other file", "/tmp/stdin.txt", nil, Params{"width": 101, "height": 103}, false},
+		{"override", "day14/files/test.txt", Params{"width": 5}, Params{"width": 5, "height": 7}, false},
+		{"unknown override", "day14/files/test.txt", Params{"depth": 5}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := info.ParamsFor(tt.path, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParamsFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !maps.Equal(got, tt.want) {
+				t.Errorf("ParamsFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	// the day's own sets are never changed by an override
+	if info.Params["test"]["width"] != 11 {
+		t.Errorf("ParamsFor() changed the day's parameters to %v", info.Params["test"])
+	}
+}
+
+func TestInputParams(t *testing.T) {
+	sets := map[string]Params{"input": {"width": 101}}
+	if got, ok := InputParams(sets, "/tmp/stdin.txt"); !ok || got["width"] != 101 {
+		t.Errorf("InputParams(stdin.txt) = %v, %t, want the set for input", got, ok)
+	}
+	if got, ok := InputParams(sets, "day14/files/test2.txt"); ok {
+		t.Errorf("InputParams(test2.txt) = %v, want no set, as examples never use the set for input", got)
+	}
+}
@@ -5,96 +5,165 @@ type StandardComparable[T any] interface {
 	Compare(T) int
 }
 
+// PriorityQueue holds items ordered by Compare, smallest first.
 type PriorityQueue[T StandardComparable[T]] interface {
-	// Insert inserts an item into the priority queue
-	Insert(T)
+	// Insert inserts an item into the priority queue, and returns a handle to
+	// it, which can be used to change its priority later.
+	Insert(T) *Handle[T]
 	// Remove removes the top item from the priority queue.
 	Remove() T
+	// Peek returns the top item without removing it.
+	Peek() T
+	// Update replaces the item referred to by handle with item, reordering the
+	// queue for its new priority. Returns false if the item has already been
+	// removed.
+	Update(handle *Handle[T], item T) bool
+	// DecreaseKey replaces the item referred to by handle with item, as
+	// Update does, but only if item comes before it. Returns false, leaving
+	// the queue unchanged, if it doesn't, or if the item has already been
+	// removed.
+	DecreaseKey(handle *Handle[T], item T) bool
 	// Size returns the number of items in the priority queue
 	Size() int
 	// IsEmpty returns true if the priority queue is empty
 	IsEmpty() bool
 }
 
+// Handle refers to an item inserted into a priority queue.
+type Handle[T any] struct {
+	item T
+	// index is the item's position in the queue's heap, or -1 once it has
+	// been removed.
+	index int
+}
+
+// Item returns the item the handle refers to.
+func (h *Handle[T]) Item() T {
+	return h.item
+}
+
+// Queued returns true if the item has not yet been removed from its queue.
+func (h *Handle[T]) Queued() bool {
+	return h.index >= 0
+}
+
+// ArrayPriorityQueue is a binary min-heap stored in a slice, with the root at
+// index 0, so the children of the node at i are at 2i+1 and 2i+2.
 type ArrayPriorityQueue[T StandardComparable[T]] struct {
-	data []T
-	size int
+	data []*Handle[T]
 }
 
 func NewArrayPriorityQueue[T StandardComparable[T]]() *ArrayPriorityQueue[T] {
-	return &ArrayPriorityQueue[T]{make([]T, 0), 0}
+	return &ArrayPriorityQueue[T]{make([]*Handle[T], 0)}
 }
 
-func (q *ArrayPriorityQueue[T]) Insert(item T) {
-	if q.size == len(q.data) {
-		q.data = append(q.data, item)
-	} else {
-		q.data[q.size] = item
-	}
-	q.size++
-	q.percolateUp()
+func (q *ArrayPriorityQueue[T]) Insert(item T) *Handle[T] {
+	handle := &Handle[T]{item, len(q.data)}
+	q.data = append(q.data, handle)
+	q.percolateUp(handle.index)
+	return handle
 }
 
 func (q *ArrayPriorityQueue[T]) Remove() T {
-	if q.size == 0 {
+	if len(q.data) == 0 {
 		panic("empty queue")
 	}
 	top := q.data[0]
-	q.data[0] = q.data[q.size-1]
-	q.size--
-	q.percolateDown()
-	return top
+	last := len(q.data) - 1
+	q.swap(0, last)
+	q.data[last] = nil
+	q.data = q.data[:last]
+	q.percolateDown(0)
+	top.index = -1
+	return top.item
+}
+
+func (q *ArrayPriorityQueue[T]) Peek() T {
+	if len(q.data) == 0 {
+		panic("empty queue")
+	}
+	return q.data[0].item
+}
+
+func (q *ArrayPriorityQueue[T]) Update(handle *Handle[T], item T) bool {
+	if !q.holds(handle) {
+		return false
+	}
+	handle.item = item
+	if !q.percolateUp(handle.index) {
+		q.percolateDown(handle.index)
+	}
+	return true
+}
+
+func (q *ArrayPriorityQueue[T]) DecreaseKey(handle *Handle[T], item T) bool {
+	if !q.holds(handle) || item.Compare(handle.item) >= 0 {
+		return false
+	}
+	handle.item = item
+	q.percolateUp(handle.index)
+	return true
 }
 
 func (q *ArrayPriorityQueue[T]) Size() int {
-	return q.size
+	return len(q.data)
 }
 
 func (q *ArrayPriorityQueue[T]) IsEmpty() bool {
 	return q.Size() == 0
 }
 
-func (q *ArrayPriorityQueue[T]) percolateUp() {
-	nodeIndex := q.size - 1
-	parentIndex := q.parentIndex(nodeIndex)
-	for nodeIndex > 0 && q.data[nodeIndex].Compare(q.data[parentIndex]) < 0 {
-		q.swap(nodeIndex, parentIndex)
-		nodeIndex = parentIndex
-		parentIndex = q.parentIndex(nodeIndex)
+// holds returns true if handle refers to an item still in this queue.
+func (q *ArrayPriorityQueue[T]) holds(handle *Handle[T]) bool {
+	return handle.index >= 0 && handle.index < len(q.data) && q.data[handle.index] == handle
+}
+
+// percolateUp moves the node at index up until its parent comes before it,
+// and returns true if it moved.
+func (q *ArrayPriorityQueue[T]) percolateUp(index int) bool {
+	moved := false
+	for index > 0 {
+		parent := q.parentIndex(index)
+		if q.data[index].item.Compare(q.data[parent].item) >= 0 {
+			break
+		}
+		q.swap(index, parent)
+		index = parent
+		moved = true
 	}
+	return moved
 }
 
-func (q *ArrayPriorityQueue[T]) percolateDown() {
-	nodeIndex := 0
-	leftChild, rightChild := q.childrenIndices(nodeIndex)
+// percolateDown moves the node at index down until it comes before both of
+// its children.
+func (q *ArrayPriorityQueue[T]) percolateDown(index int) {
 	for {
-		smallestChild := -1
-		if leftChild < q.size && q.data[leftChild].Compare(q.data[nodeIndex]) < 0 {
-			smallestChild = leftChild
+		smallest := index
+		leftChild, rightChild := q.childrenIndices(index)
+		if leftChild < len(q.data) && q.data[leftChild].item.Compare(q.data[smallest].item) < 0 {
+			smallest = leftChild
 		}
-		if rightChild < q.size && q.data[rightChild].Compare(q.data[nodeIndex]) < 0 && q.data[rightChild].Compare(q.data[leftChild]) < 0 {
-			smallestChild = rightChild
+		if rightChild < len(q.data) && q.data[rightChild].item.Compare(q.data[smallest].item) < 0 {
+			smallest = rightChild
 		}
-		if smallestChild == -1 {
-			break
-		} else {
-			q.swap(nodeIndex, smallestChild)
-			nodeIndex = smallestChild
+		if smallest == index {
+			return
 		}
-		leftChild, rightChild = q.childrenIndices(nodeIndex)
+		q.swap(index, smallest)
+		index = smallest
 	}
 }
 
 func (q *ArrayPriorityQueue[T]) swap(one, two int) {
-	temp := q.data[one]
-	q.data[one] = q.data[two]
-	q.data[two] = temp
+	q.data[one], q.data[two] = q.data[two], q.data[one]
+	q.data[one].index = one
+	q.data[two].index = two
 }
 
 func (q *ArrayPriorityQueue[T]) parentIndex(index int) int {
-	return index / 2
+	return (index - 1) / 2
 }
 
 func (q *ArrayPriorityQueue[T]) childrenIndices(index int) (int, int) {
-	return 2 * index, 2*index + 1
+	return 2*index + 1, 2*index + 2
 }
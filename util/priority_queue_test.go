@@ -1,6 +1,11 @@
 package util
 
-import "testing"
+import (
+	"cmp"
+	"container/heap"
+	"testing"
+	"testing/quick"
+)
 
 type TestComparable struct {
 	value int
@@ -96,3 +101,156 @@ func TestPriorityQueue_StrongerRemove(t *testing.T) {
 		t.Errorf("IsEmpty() = false, want true")
 	}
 }
+
+func TestPriorityQueue_Peek(t *testing.T) {
+	q := NewArrayPriorityQueue[TestComparable]()
+	q.Insert(TestComparable{3})
+	q.Insert(TestComparable{1})
+	if q.Peek().value != 1 {
+		t.Errorf("Peek() = %d, want 1", q.Peek().value)
+	}
+	if q.Size() != 2 {
+		t.Errorf("Size() = %d after Peek(), want 2", q.Size())
+	}
+}
+
+func TestPriorityQueue_Update(t *testing.T) {
+	q := NewArrayPriorityQueue[TestComparable]()
+	one := q.Insert(TestComparable{1})
+	two := q.Insert(TestComparable{2})
+	q.Insert(TestComparable{3})
+	if !q.Update(one, TestComparable{4}) {
+		t.Errorf("Update() = false, want true")
+	}
+	if !q.DecreaseKey(two, TestComparable{0}) {
+		t.Errorf("DecreaseKey() = false, want true")
+	}
+	if q.DecreaseKey(two, TestComparable{5}) {
+		t.Errorf("DecreaseKey() to a greater item = true, want false")
+	}
+	for _, want := range []int{0, 3, 4} {
+		if got := q.Remove().value; got != want {
+			t.Errorf("Remove() = %d, want %d", got, want)
+		}
+	}
+	if one.Queued() || q.Update(one, TestComparable{1}) {
+		t.Errorf("Update() of a removed item = true, want false")
+	}
+}
+
+// propItem is ordered by value, and then by id, so that no two items tie and
+// every heap removes them in the same order.
+type propItem struct {
+	value, id int
+}
+
+func (i propItem) Compare(other propItem) int {
+	return cmp.Or(cmp.Compare(i.value, other.value), cmp.Compare(i.id, other.id))
+}
+
+// refHeap is a reference priority queue built on container/heap.
+type refHeap []*refEntry
+
+type refEntry struct {
+	item  propItem
+	index int
+}
+
+func (h refHeap) Len() int           { return len(h) }
+func (h refHeap) Less(i, j int) bool { return h[i].item.Compare(h[j].item) < 0 }
+func (h refHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *refHeap) Push(x any) {
+	entry := x.(*refEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+func (h *refHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	entry.index = -1
+	return entry
+}
+
+// TestPriorityQueue_MatchesContainerHeap applies random sequences of inserts,
+// removals, updates and key decreases to an ArrayPriorityQueue and to a heap
+// from container/heap, and checks that they always agree.
+func TestPriorityQueue_MatchesContainerHeap(t *testing.T) {
+	property := func(ops []uint8, values []int8) bool {
+		q := NewArrayPriorityQueue[propItem]()
+		ref := &refHeap{}
+		handles := make([]*Handle[propItem], 0)
+		entries := make([]*refEntry, 0)
+		for i, op := range ops {
+			value := 0
+			if len(values) > 0 {
+				value = int(values[i%len(values)])
+			}
+			switch op % 4 {
+			case 0, 1:
+				item := propItem{value, len(handles)}
+				handles = append(handles, q.Insert(item))
+				entry := &refEntry{item: item}
+				heap.Push(ref, entry)
+				entries = append(entries, entry)
+			case 2:
+				if ref.Len() == 0 {
+					continue
+				}
+				if got, want := q.Remove(), heap.Pop(ref).(*refEntry).item; got != want {
+					t.Logf("Remove() = %v, want %v", got, want)
+					return false
+				}
+			case 3:
+				if len(handles) == 0 {
+					continue
+				}
+				k := int(op/4) % len(handles)
+				item := propItem{value, handles[k].Item().id}
+				if entries[k].index < 0 {
+					if q.Update(handles[k], item) || q.DecreaseKey(handles[k], item) {
+						t.Logf("Update() of a removed item = true, want false")
+						return false
+					}
+					continue
+				}
+				decrease := item.Compare(entries[k].item) < 0
+				var ok bool
+				if op&0x80 != 0 {
+					ok = q.DecreaseKey(handles[k], item)
+				} else {
+					ok, decrease = q.Update(handles[k], item), true
+				}
+				if ok != decrease {
+					t.Logf("changing %v to %v = %t, want %t", entries[k].item, item, ok, decrease)
+					return false
+				}
+				if decrease {
+					entries[k].item = item
+					heap.Fix(ref, entries[k].index)
+				}
+			}
+			if q.Size() != ref.Len() {
+				t.Logf("Size() = %d, want %d", q.Size(), ref.Len())
+				return false
+			}
+			if ref.Len() > 0 && q.Peek() != (*ref)[0].item {
+				t.Logf("Peek() = %v, want %v", q.Peek(), (*ref)[0].item)
+				return false
+			}
+		}
+		for ref.Len() > 0 {
+			if got, want := q.Remove(), heap.Pop(ref).(*refEntry).item; got != want {
+				t.Logf("Remove() = %v, want %v", got, want)
+				return false
+			}
+		}
+		return q.IsEmpty()
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
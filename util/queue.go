@@ -1,39 +1,100 @@
 package util
 
+import "iter"
+
 type Queue[T any] interface {
-	// Insert inserts an item into the queue
+	// Insert inserts an item at the back of the queue
 	Insert(T)
-	// Remove removes the top item from the queue.
-	Remove() T
+	// Remove removes the item at the front of the queue, and returns false if
+	// the queue is empty.
+	Remove() (T, bool)
+	// Peek returns the item at the front of the queue without removing it, and
+	// returns false if the queue is empty.
+	Peek() (T, bool)
 	// Size returns the number of items in the queue
 	Size() int
 	// IsEmpty returns true if the queue is empty
 	IsEmpty() bool
 }
 
+// minQueueCapacity is the capacity an ArrayQueue first grows to.
+const minQueueCapacity = 8
+
+// ArrayQueue is a double ended queue stored in a ring buffer, which grows as
+// needed and reuses the space of removed items.
 type ArrayQueue[T any] struct {
-	arr  []T
+	arr []T
+	// head is the index in arr of the front of the queue.
+	head int
 	size int
 }
 
 func NewArrayQueue[T any]() *ArrayQueue[T] {
-	return &ArrayQueue[T]{make([]T, 0), 0}
+	return &ArrayQueue[T]{}
 }
 
+// Insert inserts an item at the back of the queue.
 func (q *ArrayQueue[T]) Insert(t T) {
-	if len(q.arr) == q.size {
-		q.arr = append(q.arr, t)
-	} else {
-		q.arr[q.size] = t
-	}
+	q.grow()
+	q.arr[q.index(q.size)] = t
 	q.size++
 }
 
-func (q *ArrayQueue[T]) Remove() T {
-	el := q.arr[0]
-	q.arr = q.arr[1:]
+// PushFront inserts an item at the front of the queue.
+func (q *ArrayQueue[T]) PushFront(t T) {
+	q.grow()
+	q.head = q.index(len(q.arr) - 1)
+	q.arr[q.head] = t
+	q.size++
+}
+
+// Remove removes the item at the front of the queue, and returns false if the
+// queue is empty.
+func (q *ArrayQueue[T]) Remove() (T, bool) {
+	var zero T
+	if q.size == 0 {
+		return zero, false
+	}
+	el := q.arr[q.head]
+	// clear the slot, so that the queue doesn't keep the item alive
+	q.arr[q.head] = zero
+	q.head = q.index(1)
 	q.size--
-	return el
+	return el, true
+}
+
+// PopBack removes the item at the back of the queue, and returns false if the
+// queue is empty.
+func (q *ArrayQueue[T]) PopBack() (T, bool) {
+	var zero T
+	if q.size == 0 {
+		return zero, false
+	}
+	last := q.index(q.size - 1)
+	el := q.arr[last]
+	q.arr[last] = zero
+	q.size--
+	return el, true
+}
+
+// Peek returns the item at the front of the queue without removing it, and
+// returns false if the queue is empty.
+func (q *ArrayQueue[T]) Peek() (T, bool) {
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.arr[q.head], true
+}
+
+// PeekBack returns the item at the back of the queue without removing it, and
+// returns false if the queue is empty.
+func (q *ArrayQueue[T]) PeekBack() (T, bool) {
+	if q.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.arr[q.index(q.size-1)], true
 }
 
 func (q *ArrayQueue[T]) Size() int {
@@ -44,8 +105,39 @@ func (q *ArrayQueue[T]) IsEmpty() bool {
 	return q.size == 0
 }
 
+// All returns an iterator over the items in the queue, from front to back.
+// The queue must not be changed while iterating.
+func (q *ArrayQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range q.size {
+			if !yield(q.arr[q.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// ToArray returns a copy of the items in the queue, from front to back.
 func (q *ArrayQueue[T]) ToArray() []T {
 	newArray := make([]T, q.size)
-	copy(newArray, q.arr)
+	n := copy(newArray, q.arr[q.head:min(q.head+q.size, len(q.arr))])
+	copy(newArray[n:], q.arr)
 	return newArray
 }
+
+// index returns the index in arr of the item i places from the front.
+func (q *ArrayQueue[T]) index(i int) int {
+	return (q.head + i) % len(q.arr)
+}
+
+// grow makes room for one more item, doubling the buffer if it is full, and
+// moving the items to its start.
+func (q *ArrayQueue[T]) grow() {
+	if q.size < len(q.arr) {
+		return
+	}
+	arr := make([]T, max(2*len(q.arr), minQueueCapacity))
+	copy(arr, q.ToArray())
+	q.arr = arr
+	q.head = 0
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestArrayQueue_EmptyAtStart(t *testing.T) {
+	q := NewArrayQueue[int]()
+	if !q.IsEmpty() || q.Size() != 0 {
+		t.Errorf("IsEmpty(), Size() = %t, %d, want true, 0", q.IsEmpty(), q.Size())
+	}
+	if _, ok := q.Remove(); ok {
+		t.Errorf("Remove() on an empty queue ok = true, want false")
+	}
+	if _, ok := q.PopBack(); ok {
+		t.Errorf("PopBack() on an empty queue ok = true, want false")
+	}
+	if _, ok := q.Peek(); ok {
+		t.Errorf("Peek() on an empty queue ok = true, want false")
+	}
+	if _, ok := q.PeekBack(); ok {
+		t.Errorf("PeekBack() on an empty queue ok = true, want false")
+	}
+	if got := q.ToArray(); len(got) != 0 {
+		t.Errorf("ToArray() = %v, want []", got)
+	}
+}
+
+func TestArrayQueue_FirstInFirstOut(t *testing.T) {
+	q := NewArrayQueue[int]()
+	for i := range 20 {
+		q.Insert(i)
+	}
+	if got, ok := q.Peek(); !ok || got != 0 {
+		t.Errorf("Peek() = %d, %t, want 0, true", got, ok)
+	}
+	for i := range 20 {
+		if got, ok := q.Remove(); !ok || got != i {
+			t.Fatalf("Remove() = %d, %t, want %d, true", got, ok, i)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("IsEmpty() = false after removing everything, want true")
+	}
+}
+
+func TestArrayQueue_DequeOperations(t *testing.T) {
+	q := NewArrayQueue[int]()
+	q.Insert(2)
+	q.PushFront(1)
+	q.Insert(3)
+	q.PushFront(0)
+	if got := q.ToArray(); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("ToArray() = %v, want [0 1 2 3]", got)
+	}
+	if got, ok := q.PeekBack(); !ok || got != 3 {
+		t.Errorf("PeekBack() = %d, %t, want 3, true", got, ok)
+	}
+	if got, ok := q.PopBack(); !ok || got != 3 {
+		t.Errorf("PopBack() = %d, %t, want 3, true", got, ok)
+	}
+	if got, ok := q.Remove(); !ok || got != 0 {
+		t.Errorf("Remove() = %d, %t, want 0, true", got, ok)
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("All() = %v, want [1 2]", got)
+	}
+}
+
+// TestArrayQueue_WrapsAround checks the queue against a slice while the front
+// of the queue moves round the ring buffer, and the buffer grows.
+func TestArrayQueue_WrapsAround(t *testing.T) {
+	q := NewArrayQueue[int]()
+	var want []int
+	for i := range 200 {
+		switch i % 5 {
+		case 0, 1:
+			q.Insert(i)
+			want = append(want, i)
+		case 2:
+			q.PushFront(i)
+			want = append([]int{i}, want...)
+		case 3:
+			q.Remove()
+			want = want[1:]
+		}
+		if got := q.ToArray(); !slices.Equal(got, want) {
+			t.Fatalf("after step %d ToArray() = %v, want %v", i, got, want)
+		}
+		if got := slices.Collect(q.All()); !slices.Equal(got, want) {
+			t.Fatalf("after step %d All() = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestArrayQueue_ReusesMemory(t *testing.T) {
+	q := NewArrayQueue[int]()
+	for i := range 1000 {
+		q.Insert(i)
+		q.Remove()
+	}
+	if len(q.arr) != minQueueCapacity {
+		t.Errorf("buffer length = %d after 1000 inserts and removes, want %d", len(q.arr), minQueueCapacity)
+	}
+}
+
+func BenchmarkArrayQueue_SlidingWindow(b *testing.B) {
+	q := NewArrayQueue[int]()
+	for i := range 4 {
+		q.Insert(i)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.Insert(i)
+		q.Remove()
+	}
+}
+
+func BenchmarkArrayQueue_FillAndDrain(b *testing.B) {
+	q := NewArrayQueue[int]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := range 1000 {
+			q.Insert(j)
+		}
+		for !q.IsEmpty() {
+			q.Remove()
+		}
+	}
+}
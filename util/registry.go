@@ -0,0 +1,101 @@
+package util
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// DefaultYear is the year run when no other is given.
+const DefaultYear = 2024
+
+// DayInfo describes a solved day: the puzzle it solves, and how to create its
+// solution.
+type DayInfo struct {
+	Year  int
+	Day   int
+	Title string
+	// NewSolution creates the solution for the input file designated by
+	// filepath, configured by config.
+	NewSolution func(filepath string, config SolutionConfig) (Solution, error)
+	// Params holds the parameters the day is solved with, keyed by the name
+	// of the input they are for, such as "test". Nil if the day has none.
+	Params map[string]Params
+}
+
+// URL returns the address of the day's puzzle.
+func (d DayInfo) URL() string {
+	return fmt.Sprintf("https://adventofcode.com/%d/day/%d", d.Year, d.Day)
+}
+
+// Dir returns the directory holding the day's package and files, relative to
+// the module root.
+func (d DayInfo) Dir() string {
+	return DayDir(d.Year, d.Day)
+}
+
+// DayDir returns the directory holding the package and files for the given
+// year and day, relative to the module root. Days from DefaultYear predate
+// support for other years, and live at the top level. Every other year lives
+// in a directory named for the year, such as 2023/day01.
+func DayDir(year, day int) string {
+	if year == DefaultYear {
+		return fmt.Sprintf("day%02d", day)
+	}
+	return fmt.Sprintf("%d/day%02d", year, day)
+}
+
+// dayKey identifies a day in the registry.
+type dayKey struct {
+	year, day int
+}
+
+// registry maps each year and day to its registered day.
+var registry = make(map[dayKey]DayInfo)
+
+// RegisterDay adds a day to the registry, so it can be found by the runner.
+// Each day's package calls it from an init function. It panics if the day is
+// already registered.
+func RegisterDay(info DayInfo) {
+	key := dayKey{info.Year, info.Day}
+	if _, ok := registry[key]; ok {
+		panic(fmt.Sprintf("day %d of %d is already registered", info.Day, info.Year))
+	}
+	registry[key] = info
+}
+
+// LookupDay returns the registered day for the given year and day number, and
+// whether there is one.
+func LookupDay(year, day int) (DayInfo, bool) {
+	info, ok := registry[dayKey{year, day}]
+	return info, ok
+}
+
+// Days returns every registered day, ordered by year and then by day.
+func Days() []DayInfo {
+	return slices.SortedFunc(maps.Values(registry), func(a, b DayInfo) int {
+		return cmp.Or(cmp.Compare(a.Year, b.Year), cmp.Compare(a.Day, b.Day))
+	})
+}
+
+// DaysInYear returns every registered day for year in ascending order.
+func DaysInYear(year int) []DayInfo {
+	days := make([]DayInfo, 0)
+	for _, info := range Days() {
+		if info.Year == year {
+			days = append(days, info)
+		}
+	}
+	return days
+}
+
+// Years returns every year with at least one registered day, in ascending
+// order.
+func Years() []int {
+	years := NewSet[int]()
+	for key := range registry {
+		years.Add(key.year)
+	}
+	return Sorted(years)
+}
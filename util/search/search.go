@@ -0,0 +1,209 @@
+// Package search finds least cost paths through graphs given implicitly, as a
+// start state and a function returning the neighbors of each state. A state
+// can be any comparable type, such as a util.Vector, or a struct holding a
+// position and a direction.
+//
+// Every search records all of the predecessors of each state on its least
+// cost paths, not just one, so that the states on every optimal path can be
+// found, and the optimal paths counted.
+package search
+
+import (
+	"advent/util"
+	"iter"
+	"maps"
+)
+
+// Edge is a step to a neighboring state, and what it costs.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Result is what a search found: the least cost of reaching each state it
+// reached from the start, and how.
+type Result[S comparable] struct {
+	Start S
+	costs map[S]int
+	// predecessors maps each state reached, other than the start, to every
+	// state directly before it on a least cost path.
+	predecessors map[S][]S
+}
+
+func newResult[S comparable](start S) *Result[S] {
+	return &Result[S]{start, map[S]int{start: 0}, make(map[S][]S)}
+}
+
+// BFS searches outward from start, where every step costs 1, and returns the
+// least number of steps to each state reachable from it.
+func BFS[S comparable](start S, neighbors func(S) []S) *Result[S] {
+	result := newResult(start)
+	toVisit := util.NewArrayQueue[S]()
+	toVisit.Insert(start)
+	for !toVisit.IsEmpty() {
+		current, _ := toVisit.Remove()
+		cost := result.costs[current] + 1
+		for _, neighbor := range neighbors(current) {
+			found, ok := result.costs[neighbor]
+			if !ok {
+				result.costs[neighbor] = cost
+				toVisit.Insert(neighbor)
+			}
+			if !ok || found == cost {
+				result.predecessors[neighbor] = append(result.predecessors[neighbor], current)
+			}
+		}
+	}
+	return result
+}
+
+// Dijkstra searches outward from start, where each step costs as given by its
+// edge, and returns the least cost of reaching each state reachable from it.
+// Costs must not be negative.
+func Dijkstra[S comparable](start S, neighbors func(S) []Edge[S]) *Result[S] {
+	result, _, _ := AStar(start, neighbors, nil, nil)
+	return result
+}
+
+// AStar searches outward from start, as Dijkstra does, but stops once it has
+// found every least cost path to the nearest goal, a state for which isGoal
+// returns true. The search is guided by heuristic, which estimates the cost
+// from a state to the nearest goal. For the paths found to be least cost, the
+// heuristic must never overestimate, and must never fall by more than the
+// cost of a step. A nil heuristic estimates 0 everywhere, and a nil isGoal
+// searches every reachable state. Returns the goal reached, and whether one
+// was.
+func AStar[S comparable](start S, neighbors func(S) []Edge[S], isGoal func(S) bool, heuristic func(S) int) (*Result[S], S, bool) {
+	if heuristic == nil {
+		heuristic = func(S) int { return 0 }
+	}
+	result := newResult(start)
+	toVisit := util.NewArrayPriorityQueue[queued[S]]()
+	handles := map[S]*util.Handle[queued[S]]{start: toVisit.Insert(queued[S]{start, heuristic(start)})}
+	var goal S
+	goalCost := -1
+	for !toVisit.IsEmpty() {
+		current := toVisit.Remove()
+		if goalCost >= 0 && current.priority > goalCost {
+			break
+		}
+		cost := result.costs[current.state]
+		if isGoal != nil && isGoal(current.state) {
+			if goalCost < 0 {
+				goal, goalCost = current.state, cost
+			}
+			continue
+		}
+		for _, edge := range neighbors(current.state) {
+			newCost := cost + edge.Cost
+			found, ok := result.costs[edge.To]
+			switch {
+			case !ok || newCost < found:
+				result.costs[edge.To] = newCost
+				result.predecessors[edge.To] = []S{current.state}
+				next := queued[S]{edge.To, newCost + heuristic(edge.To)}
+				if handle, ok := handles[edge.To]; ok && handle.Queued() {
+					toVisit.DecreaseKey(handle, next)
+				} else {
+					handles[edge.To] = toVisit.Insert(next)
+				}
+			case newCost == found:
+				result.predecessors[edge.To] = append(result.predecessors[edge.To], current.state)
+			}
+		}
+	}
+	return result, goal, goalCost >= 0
+}
+
+// queued is a state waiting to be visited, ordered by its priority: the cost
+// of reaching it plus the heuristic's estimate of the cost from it.
+type queued[S comparable] struct {
+	state    S
+	priority int
+}
+
+func (q queued[S]) Compare(other queued[S]) int {
+	return q.priority - other.priority
+}
+
+// Cost returns the least cost of reaching state from the start, and whether
+// it was reached.
+func (r *Result[S]) Cost(state S) (int, bool) {
+	cost, ok := r.costs[state]
+	return cost, ok
+}
+
+// Reached returns an iterator over every state reached from the start,
+// including the start, in no particular order.
+func (r *Result[S]) Reached() iter.Seq[S] {
+	return maps.Keys(r.costs)
+}
+
+// Predecessors returns every state directly before state on a least cost path
+// to it.
+func (r *Result[S]) Predecessors(state S) []S {
+	return r.predecessors[state]
+}
+
+// Path returns a least cost path from the start to end, including both, or
+// nil if end was not reached.
+func (r *Result[S]) Path(end S) []S {
+	if _, ok := r.costs[end]; !ok {
+		return nil
+	}
+	path := []S{end}
+	for current := end; current != r.Start; {
+		current = r.predecessors[current][0]
+		path = append(path, current)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// OnOptimalPaths returns every state on any least cost path from the start to
+// any of ends. Ends that were not reached are left out.
+func (r *Result[S]) OnOptimalPaths(ends ...S) util.Set[S] {
+	onPath := util.NewSet[S]()
+	toVisit := make([]S, 0, len(ends))
+	for _, end := range ends {
+		if _, ok := r.costs[end]; ok && !onPath.Contains(end) {
+			onPath.Add(end)
+			toVisit = append(toVisit, end)
+		}
+	}
+	for len(toVisit) > 0 {
+		current := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		for _, predecessor := range r.predecessors[current] {
+			if !onPath.Contains(predecessor) {
+				onPath.Add(predecessor)
+				toVisit = append(toVisit, predecessor)
+			}
+		}
+	}
+	return onPath
+}
+
+// CountPaths returns the number of distinct least cost paths from the start to
+// end, or 0 if end was not reached.
+func (r *Result[S]) CountPaths(end S) int {
+	counts := map[S]int{r.Start: 1}
+	var count func(S) int
+	count = func(state S) int {
+		if n, ok := counts[state]; ok {
+			return n
+		}
+		n := 0
+		for _, predecessor := range r.predecessors[state] {
+			n += count(predecessor)
+		}
+		counts[state] = n
+		return n
+	}
+	if _, ok := r.costs[end]; !ok {
+		return 0
+	}
+	return count(end)
+}
@@ -0,0 +1,155 @@
+package search
+
+import (
+	"advent/util"
+	"slices"
+	"testing"
+)
+
+type cell struct {
+	row, col int
+}
+
+// gridNeighbors returns the open cells next to a cell of grid, where '#' is a
+// wall.
+func gridNeighbors(grid []string) func(cell) []cell {
+	return func(c cell) []cell {
+		var neighbors []cell
+		for _, d := range []cell{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+			n := cell{c.row + d.row, c.col + d.col}
+			if n.row >= 0 && n.row < len(grid) && n.col >= 0 && n.col < len(grid[n.row]) && grid[n.row][n.col] != '#' {
+				neighbors = append(neighbors, n)
+			}
+		}
+		return neighbors
+	}
+}
+
+func unitEdges(neighbors func(cell) []cell) func(cell) []Edge[cell] {
+	return func(c cell) []Edge[cell] {
+		var edges []Edge[cell]
+		for _, n := range neighbors(c) {
+			edges = append(edges, Edge[cell]{n, 1})
+		}
+		return edges
+	}
+}
+
+var testGrid = []string{
+	"...",
+	".#.",
+	"...",
+	"##.",
+}
+
+func TestBFS(t *testing.T) {
+	result := BFS(cell{0, 0}, gridNeighbors(testGrid))
+	tests := []struct {
+		to    cell
+		cost  int
+		paths int
+	}{
+		{cell{0, 0}, 0, 1},
+		{cell{0, 2}, 2, 1},
+		{cell{2, 2}, 4, 2},
+		{cell{3, 2}, 5, 2},
+	}
+	for _, tt := range tests {
+		if cost, ok := result.Cost(tt.to); !ok || cost != tt.cost {
+			t.Errorf("Cost(%v) = %d, %t, want %d, true", tt.to, cost, ok, tt.cost)
+		}
+		if paths := result.CountPaths(tt.to); paths != tt.paths {
+			t.Errorf("CountPaths(%v) = %d, want %d", tt.to, paths, tt.paths)
+		}
+		if path := result.Path(tt.to); len(path) != tt.cost+1 || path[0] != result.Start || path[len(path)-1] != tt.to {
+			t.Errorf("Path(%v) = %v, want %d steps from the start", tt.to, path, tt.cost)
+		}
+	}
+	if reached := slices.Collect(result.Reached()); len(reached) != 9 {
+		t.Errorf("Reached() = %v, want the 9 open cells", reached)
+	}
+	for _, unreached := range []cell{{1, 1}, {3, 0}} {
+		if _, ok := result.Cost(unreached); ok {
+			t.Errorf("Cost(%v) reached, want unreached", unreached)
+		}
+		if path := result.Path(unreached); path != nil {
+			t.Errorf("Path(%v) = %v, want nil", unreached, path)
+		}
+		if paths := result.CountPaths(unreached); paths != 0 {
+			t.Errorf("CountPaths(%v) = %d, want 0", unreached, paths)
+		}
+	}
+}
+
+func TestResult_OnOptimalPaths(t *testing.T) {
+	result := BFS(cell{0, 0}, gridNeighbors(testGrid))
+	got := result.OnOptimalPaths(cell{3, 2})
+	want := util.NewSet(cell{0, 0}, cell{0, 1}, cell{0, 2}, cell{1, 0}, cell{1, 2}, cell{2, 0}, cell{2, 1}, cell{2, 2}, cell{3, 2})
+	if !got.Equal(want) {
+		t.Errorf("OnOptimalPaths() = %v, want %v", got, want)
+	}
+	if got := result.OnOptimalPaths(cell{0, 2}); got.Len() != 3 {
+		t.Errorf("OnOptimalPaths() = %v, want the 3 cells of the top row", got)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	// Going down the middle costs 10, so going round is cheaper.
+	neighbors := func(n int) []Edge[int] {
+		switch n {
+		case 0:
+			return []Edge[int]{{1, 10}, {2, 1}, {4, 2}}
+		case 2:
+			return []Edge[int]{{3, 1}}
+		case 3:
+			return []Edge[int]{{1, 1}}
+		case 4:
+			return []Edge[int]{{1, 1}}
+		}
+		return nil
+	}
+	result := Dijkstra(0, neighbors)
+	if cost, ok := result.Cost(1); !ok || cost != 3 {
+		t.Errorf("Cost(1) = %d, %t, want 3, true", cost, ok)
+	}
+	if predecessors := result.Predecessors(1); !slices.Equal(slices.Sorted(slices.Values(predecessors)), []int{3, 4}) {
+		t.Errorf("Predecessors(1) = %v, want [3 4]", predecessors)
+	}
+	if paths := result.CountPaths(1); paths != 2 {
+		t.Errorf("CountPaths(1) = %d, want 2", paths)
+	}
+	if path := result.Path(4); !slices.Equal(path, []int{0, 4}) {
+		t.Errorf("Path(4) = %v, want [0 4]", path)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	grid := []string{
+		"........",
+		".######.",
+		"........",
+	}
+	goal := cell{2, 7}
+	manhattan := func(c cell) int {
+		return max(goal.row-c.row, c.row-goal.row) + max(goal.col-c.col, c.col-goal.col)
+	}
+	isGoal := func(c cell) bool { return c == goal }
+	neighbors := unitEdges(gridNeighbors(grid))
+
+	for _, heuristic := range []func(cell) int{nil, manhattan} {
+		result, found, ok := AStar(cell{0, 0}, neighbors, isGoal, heuristic)
+		if !ok || found != goal {
+			t.Fatalf("AStar() goal = %v, %t, want %v, true", found, ok, goal)
+		}
+		if cost, _ := result.Cost(goal); cost != 9 {
+			t.Errorf("Cost(goal) = %d, want 9", cost)
+		}
+		if paths := result.CountPaths(goal); paths != 2 {
+			t.Errorf("CountPaths(goal) = %d, want 2", paths)
+		}
+	}
+
+	if _, _, ok := AStar(cell{0, 0}, neighbors, func(c cell) bool { return c.row > 2 }, manhattan); ok {
+		t.Errorf("AStar() to an unreachable goal found one")
+	}
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Set is an unordered collection of distinct items. The zero value is a nil
+// set, which can be read from but not added to; use NewSet to create one.
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a set holding items.
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+// Add adds items to the set.
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+// Remove removes item from the set, if it is there.
+func (s Set[T]) Remove(item T) {
+	delete(s, item)
+}
+
+// Contains returns true if item is in the set.
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// Len returns the number of items in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone returns a copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	clone := make(Set[T], len(s))
+	for item := range s {
+		clone[item] = struct{}{}
+	}
+	return clone
+}
+
+// Union returns a new set of the items in either set.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := s.Clone()
+	for item := range other {
+		union[item] = struct{}{}
+	}
+	return union
+}
+
+// Intersection returns a new set of the items in both sets.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	if len(other) < len(s) {
+		s, other = other, s
+	}
+	intersection := make(Set[T])
+	for item := range s {
+		if other.Contains(item) {
+			intersection[item] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new set of the items in s that are not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	difference := make(Set[T])
+	for item := range s {
+		if !other.Contains(item) {
+			difference[item] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// IsSubsetOf returns true if every item in s is also in other.
+func (s Set[T]) IsSubsetOf(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for item := range s {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both sets hold the same items.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubsetOf(other)
+}
+
+// All returns an iterator over the items in the set, in no particular order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// SortedFunc returns the items in the set, sorted by compare.
+func (s Set[T]) SortedFunc(compare func(a, b T) int) []T {
+	return slices.SortedFunc(maps.Keys(s), compare)
+}
+
+// Sorted returns the items in the set in ascending order.
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	return slices.Sorted(maps.Keys(s))
+}
+
+// String returns the items in the set, formatted as with %v, and sorted by
+// their text, so that equal sets give the same string, such as {a, b, c}.
+func (s Set[T]) String() string {
+	items := make([]string, 0, len(s))
+	for item := range s {
+		items = append(items, fmt.Sprint(item))
+	}
+	slices.Sort(items)
+	return "{" + strings.Join(items, ", ") + "}"
+}
@@ -0,0 +1,91 @@
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSet_AddRemoveContains(t *testing.T) {
+	s := NewSet(1, 2, 2)
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", s.Len())
+	}
+	s.Add(3)
+	s.Remove(1)
+	s.Remove(4)
+	for item, want := range map[int]bool{1: false, 2: true, 3: true, 4: false} {
+		if got := s.Contains(item); got != want {
+			t.Errorf("Contains(%d) = %t, want %t", item, got, want)
+		}
+	}
+}
+
+func TestSet_Vectors(t *testing.T) {
+	// positions are deduplicated by value, however they were made
+	s := NewSet(NewVector(1, 2))
+	s.Add(NewVector(0, 1).Add(NewVector(1, 1)))
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", s.Len())
+	}
+}
+
+func TestSet_Operations(t *testing.T) {
+	one := NewSet(1, 2, 3)
+	two := NewSet(3, 4)
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"Union", one.Union(two), []int{1, 2, 3, 4}},
+		{"Intersection", one.Intersection(two), []int{3}},
+		{"Difference", one.Difference(two), []int{1, 2}},
+		{"Clone", one.Clone(), []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		if got := Sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := Sorted(one); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("operations changed the set to %v, want [1 2 3]", got)
+	}
+}
+
+func TestSet_IsSubsetOf(t *testing.T) {
+	tests := []struct {
+		s, other Set[string]
+		want     bool
+	}{
+		{NewSet("a"), NewSet("a", "b"), true},
+		{NewSet("a", "b"), NewSet("a", "b"), true},
+		{NewSet[string](), NewSet("a"), true},
+		{NewSet("a", "c"), NewSet("a", "b"), false},
+		{NewSet("a", "b"), NewSet("a"), false},
+	}
+	for _, tt := range tests {
+		if got := tt.s.IsSubsetOf(tt.other); got != tt.want {
+			t.Errorf("%v.IsSubsetOf(%v) = %t, want %t", tt.s, tt.other, got, tt.want)
+		}
+	}
+	if !NewSet(1, 2).Equal(NewSet(2, 1)) || NewSet(1, 2).Equal(NewSet(1, 3)) {
+		t.Errorf("Equal() compared sets wrongly")
+	}
+}
+
+func TestSet_SortedAndString(t *testing.T) {
+	s := NewSet("tc", "co", "ka")
+	if got := Sorted(s); !slices.Equal(got, []string{"co", "ka", "tc"}) {
+		t.Errorf("Sorted() = %v, want [co ka tc]", got)
+	}
+	byLength := NewSet("ccc", "a", "bb").SortedFunc(func(a, b string) int { return len(a) - len(b) })
+	if !slices.Equal(byLength, []string{"a", "bb", "ccc"}) {
+		t.Errorf("SortedFunc() = %v, want [a bb ccc]", byLength)
+	}
+	if got := s.String(); got != "{co, ka, tc}" {
+		t.Errorf("String() = %q, want {co, ka, tc}", got)
+	}
+	if got := NewSet[int]().String(); got != "{}" {
+		t.Errorf("String() of an empty set = %q, want {}", got)
+	}
+}
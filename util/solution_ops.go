@@ -1,7 +1,71 @@
 package util
 
-// Solution is an interface to be used by a solution for any given day.
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Solution is an interface to be used by a solution for any given day. Each
+// part should stop and return the error from CheckContext once ctx is done.
 type Solution interface {
-	PartOneAnswer() (int, error)
-	PartTwoAnswer() (int, error)
+	PartOneAnswer(ctx context.Context) (Answer, error)
+	PartTwoAnswer(ctx context.Context) (Answer, error)
+}
+
+// Preparer is implemented by a solution with work that both parts share, such
+// as a search whose result answers both. The runner calls Prepare before
+// either part, as a phase of its own, so that the shared work is not charged
+// to whichever part happens to ask for it first. Each part must still do the
+// work itself if Prepare was not called, or returned an error.
+type Preparer interface {
+	Prepare(ctx context.Context) error
+}
+
+// SolutionConfig holds everything passed to a solution besides its input.
+type SolutionConfig struct {
+	// Logger receives the solution's debug traces. It is never nil.
+	Logger *slog.Logger
+	// Params holds the values of the day's parameters for this input, with
+	// every parameter in the day's DayInfo.Params set.
+	Params Params
+}
+
+// NewLogger returns a logger writing to w. Only warnings and errors are logged,
+// unless verbose is true, in which case debug traces are logged too. Times are
+// left out, as the runner already times each part.
+func NewLogger(w io.Writer, verbose bool) *slog.Logger {
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+// ErrTimedOut is returned by a solution that was stopped before finding its
+// answer because it ran out of time.
+var ErrTimedOut = errors.New("timed out")
+
+// CheckContext returns nil if ctx is not done. Otherwise it returns
+// ErrTimedOut if ctx passed its deadline, or the cause of its cancellation.
+// Long-running loops in solutions should call it regularly.
+func CheckContext(ctx context.Context) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimedOut
+	}
+	return fmt.Errorf("cancelled before an answer was found: %w", context.Cause(ctx))
 }
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckContext(t *testing.T) {
+	if err := CheckContext(context.Background()); err != nil {
+		t.Errorf("CheckContext(Background) = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	if err := CheckContext(ctx); err != nil {
+		t.Errorf("CheckContext(before deadline) = %v, want nil", err)
+	}
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	if err := CheckContext(expired); !errors.Is(err, ErrTimedOut) {
+		t.Errorf("CheckContext(after deadline) = %v, want %v", err, ErrTimedOut)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	err := CheckContext(cancelled)
+	if err == nil || errors.Is(err, ErrTimedOut) || !errors.Is(err, context.Canceled) {
+		t.Errorf("CheckContext(cancelled) = %v, want an error wrapping %v", err, context.Canceled)
+	}
+}
@@ -1,69 +1,117 @@
 package util
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
 
+// Vector is a position or an offset on a grid. It is a value, so vectors can
+// be compared with == and used as map keys, and arithmetic on them doesn't
+// allocate. Positions in a Matrix are (row, column), so X counts rows down and
+// Y counts columns across.
 type Vector struct {
 	X, Y int
 }
 
-func NewVector(x, y int) *Vector {
-	return &Vector{X: x, Y: y}
+func NewVector(x, y int) Vector {
+	return Vector{X: x, Y: y}
 }
 
-// parseVector returns a Vector from a string in the format x,y. If the string
-// is not in the correct format, an error is returned.
-func ParseVector(s string) (*Vector, error) {
-	parts := strings.Split(s, ",")
-	x, err := strconv.Atoi(parts[0])
+// ParseVector returns a Vector from a string in the format x,y, as written by
+// String. If the string is not in the correct format, an error is returned.
+func ParseVector(s string) (Vector, error) {
+	xPart, yPart, ok := strings.Cut(s, ",")
+	if !ok {
+		return Vector{}, fmt.Errorf("vector %q is not in the format x,y", s)
+	}
+	x, err := strconv.Atoi(xPart)
 	if err != nil {
-		return &Vector{}, err
+		return Vector{}, err
 	}
-	y, err := strconv.Atoi(parts[1])
+	y, err := strconv.Atoi(yPart)
 	if err != nil {
-		return &Vector{}, err
+		return Vector{}, err
 	}
 	return NewVector(x, y), nil
 }
 
-// Equals returns true if and only if both X and Y of other equals X an dY of v.
-func (v *Vector) Equals(other *Vector) bool {
-	return v.X == other.X && v.Y == other.Y
+// String returns the vector in the format x,y.
+func (v Vector) String() string {
+	return strconv.Itoa(v.X) + "," + strconv.Itoa(v.Y)
+}
+
+func (v Vector) Add(other Vector) Vector {
+	return Vector{X: v.X + other.X, Y: v.Y + other.Y}
 }
 
-func (v *Vector) Add(other *Vector) *Vector {
-	return &Vector{X: v.X + other.X, Y: v.Y + other.Y}
+func (v Vector) Sub(other Vector) Vector {
+	return Vector{X: v.X - other.X, Y: v.Y - other.Y}
 }
 
-func (v *Vector) ScalarMultiply(scalar int) *Vector {
-	return &Vector{X: v.X * scalar, Y: v.Y * scalar}
+func (v Vector) Scale(scalar int) Vector {
+	return Vector{X: v.X * scalar, Y: v.Y * scalar}
 }
 
-func (v *Vector) Modulo(other *Vector) *Vector {
-	return &Vector{X: v.X % other.X, Y: v.Y % other.Y}
+func (v Vector) Modulo(other Vector) Vector {
+	return Vector{X: v.X % other.X, Y: v.Y % other.Y}
 }
 
-func (v *Vector) MathModulo(other *Vector) *Vector {
-	return &Vector{X: MathModulo(v.X, other.X), Y: MathModulo(v.Y, other.Y)}
+func (v Vector) MathModulo(other Vector) Vector {
+	return Vector{X: MathModulo(v.X, other.X), Y: MathModulo(v.Y, other.Y)}
 }
 
-func (v *Vector) Negate() *Vector {
-	return NewVector(-v.X, -v.Y)
+func (v Vector) Negate() Vector {
+	return Vector{X: -v.X, Y: -v.Y}
 }
 
 // Unit returns the smallest manhattan distance with the same direction as
 // the original.
-func (v *Vector) Unit() *Vector {
+func (v Vector) Unit() Vector {
 	gcd := GreatestCommonDivisor(IntAbs(v.X), IntAbs(v.Y))
-	return NewVector(v.X/gcd, v.Y/gcd)
+	return Vector{X: v.X / gcd, Y: v.Y / gcd}
+}
+
+// RotateCW returns the vector turned a quarter turn clockwise, so that
+// UpDirection becomes RightDirection.
+func (v Vector) RotateCW() Vector {
+	return Vector{X: v.Y, Y: -v.X}
+}
+
+// RotateCCW returns the vector turned a quarter turn counterclockwise, so that
+// UpDirection becomes LeftDirection.
+func (v Vector) RotateCCW() Vector {
+	return Vector{X: -v.Y, Y: v.X}
+}
+
+// Neighbors4 returns the four positions next to v, in the order of
+// SimpleDirections.
+func (v Vector) Neighbors4() [4]Vector {
+	var neighbors [4]Vector
+	for i, dir := range SimpleDirections {
+		neighbors[i] = v.Add(dir)
+	}
+	return neighbors
+}
+
+// Neighbors8 returns the eight positions next to v, including diagonally, in
+// the order of AllDirections.
+func (v Vector) Neighbors8() [8]Vector {
+	var neighbors [8]Vector
+	for i, dir := range AllDirections {
+		neighbors[i] = v.Add(dir)
+	}
+	return neighbors
 }
 
-func (v *Vector) GetManhattanDistance(other *Vector) *Vector {
-	return NewVector(v.X-other.X, v.Y-other.Y)
+// ManhattanDistance returns the number of steps from v to other, moving only
+// up, down, left and right.
+func (v Vector) ManhattanDistance(other Vector) int {
+	return IntAbs(v.X-other.X) + IntAbs(v.Y-other.Y)
 }
 
-func (v *Vector) GetManhattanMagnitude() int {
-	return IntAbs(v.X) + IntAbs(v.Y)
+// ChebyshevDistance returns the number of steps from v to other, moving
+// diagonally as well.
+func (v Vector) ChebyshevDistance(other Vector) int {
+	return max(IntAbs(v.X-other.X), IntAbs(v.Y-other.Y))
 }
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestVector_Arithmetic(t *testing.T) {
+	v := NewVector(3, -2)
+	tests := []struct {
+		name string
+		got  Vector
+		want Vector
+	}{
+		{"Add", v.Add(NewVector(1, 5)), NewVector(4, 3)},
+		{"Sub", v.Sub(NewVector(1, 5)), NewVector(2, -7)},
+		{"Scale", v.Scale(3), NewVector(9, -6)},
+		{"Negate", v.Negate(), NewVector(-3, 2)},
+		{"Unit", NewVector(4, -6).Unit(), NewVector(2, -3)},
+		{"MathModulo", v.MathModulo(NewVector(2, 5)), NewVector(1, 3)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestVector_Rotate(t *testing.T) {
+	for i, dir := range SimpleDirections {
+		next := SimpleDirections[(i+1)%len(SimpleDirections)]
+		if got := dir.RotateCW(); got != next {
+			t.Errorf("%v.RotateCW() = %v, want %v", dir, got, next)
+		}
+		if got := next.RotateCCW(); got != dir {
+			t.Errorf("%v.RotateCCW() = %v, want %v", next, got, dir)
+		}
+	}
+	if got := NewVector(2, 1).RotateCW().RotateCW(); got != NewVector(-2, -1) {
+		t.Errorf("RotateCW() twice = %v, want -2,-1", got)
+	}
+}
+
+func TestVector_Neighbors(t *testing.T) {
+	v := NewVector(5, 5)
+	neighbors4 := v.Neighbors4()
+	if neighbors4[0] != NewVector(4, 5) || neighbors4[1] != NewVector(5, 6) {
+		t.Errorf("Neighbors4() = %v, want up then right first", neighbors4)
+	}
+	for _, n := range neighbors4 {
+		if v.ManhattanDistance(n) != 1 {
+			t.Errorf("Neighbors4() includes %v, which is not next to %v", n, v)
+		}
+	}
+	seen := NewSet[Vector]()
+	for _, n := range v.Neighbors8() {
+		if v.ChebyshevDistance(n) != 1 {
+			t.Errorf("Neighbors8() includes %v, which is not next to %v", n, v)
+		}
+		seen.Add(n)
+	}
+	if seen.Len() != 8 {
+		t.Errorf("Neighbors8() = %v, want 8 distinct positions", v.Neighbors8())
+	}
+}
+
+func TestVector_Distances(t *testing.T) {
+	v, other := NewVector(1, -2), NewVector(-3, 4)
+	if got := v.ManhattanDistance(other); got != 10 {
+		t.Errorf("ManhattanDistance() = %d, want 10", got)
+	}
+	if got := v.ChebyshevDistance(other); got != 6 {
+		t.Errorf("ChebyshevDistance() = %d, want 6", got)
+	}
+}
+
+func TestVector_StringParse(t *testing.T) {
+	roundTrip := func(x, y int) bool {
+		v := NewVector(x, y)
+		parsed, err := ParseVector(v.String())
+		return err == nil && parsed == v
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+	if got := NewVector(-1, 20).String(); got != "-1,20" {
+		t.Errorf("String() = %q, want -1,20", got)
+	}
+	for _, s := range []string{"", "1", "1,", "a,2", "1;2"} {
+		if _, err := ParseVector(s); err == nil {
+			t.Errorf("ParseVector(%q) error = nil, want an error", s)
+		}
+	}
+}
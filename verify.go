@@ -0,0 +1,149 @@
+package main
+
+import (
+	"advent/util"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"text/tabwriter"
+)
+
+// Verification is the comparison of a computed answer for one part against
+// its expected answer.
+type Verification struct {
+	Day   int
+	Input string
+	Part  int
+	Want  string
+	Got   string
+	Err   error
+}
+
+// Passed returns true if the part ran without error and gave the expected
+// answer.
+func (v Verification) Passed() bool {
+	return v.Err == nil && v.Got == v.Want
+}
+
+// verifyDays runs each day against every input that has expected answers
+// recorded in its answers file, and prints a table comparing the computed
+// answers against the expected ones. Only the parts run by opts are checked.
+// Days without an answers file are skipped. It returns true if every recorded
+// answer matched.
+func verifyDays(w io.Writer, days []util.DayInfo, opts RunOptions) bool {
+	verifications := make([]Verification, 0)
+	for _, info := range days {
+		expected, err := util.ReadExpectedAnswers(getAnswersFilepath(info))
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(w, "Day %d: no expected answers recorded, skipping\n", info.Day)
+			continue
+		}
+		if err != nil {
+			verifications = append(verifications, Verification{Day: info.Day, Err: err})
+			continue
+		}
+		verifications = append(verifications, verifyDay(info, expected, opts)...)
+	}
+	printVerificationTable(w, verifications)
+	for _, v := range verifications {
+		if !v.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyDay runs the day as controlled by opts against every input in expected,
+// and returns a Verification for every part run with an expected answer. Parts
+// without an expected answer are not run, and neither are inputs with none.
+func verifyDay(info util.DayInfo, expected util.ExpectedAnswers, opts RunOptions) []Verification {
+	verifications := make([]Verification, 0)
+	for _, input := range slices.Sorted(maps.Keys(expected)) {
+		inputOpts, ok := answeredParts(opts, expected, input)
+		if !ok {
+			continue
+		}
+		result := runDay(info, getNamedFilepath(opts.InputRoot, info, input), inputOpts)
+		for _, p := range slices.Sorted(maps.Keys(expected[input])) {
+			if p < 1 || p > len(result.Errors) || !inputOpts.runsPart(p) {
+				continue
+			}
+			v := Verification{Day: info.Day, Input: input, Part: p, Want: expected[input][p]}
+			if result.SetupErr != nil {
+				v.Err = result.SetupErr
+			} else if result.Errors[p-1] != nil {
+				v.Err = result.Errors[p-1]
+			} else {
+				v.Got = result.Answers[p-1].String()
+			}
+			verifications = append(verifications, v)
+		}
+	}
+	return verifications
+}
+
+// answeredParts returns opts limited to the parts with an expected answer for
+// input, since a part without one may not even terminate on an example. It
+// returns false if opts runs none of them.
+func answeredParts(opts RunOptions, expected util.ExpectedAnswers, input string) (RunOptions, bool) {
+	_, knowsPartOne := expected.Get(input, 1)
+	_, knowsPartTwo := expected.Get(input, 2)
+	runsPartOne := knowsPartOne && opts.runsPart(1)
+	runsPartTwo := knowsPartTwo && opts.runsPart(2)
+	switch {
+	case !runsPartOne && !runsPartTwo:
+		return opts, false
+	case !runsPartTwo:
+		opts.Part = 1
+	case !runsPartOne:
+		opts.Part = 2
+	}
+	return opts, true
+}
+
+// printVerificationTable writes a table of verifications to w, followed by the
+// full text of any errors and a count of failures.
+func printVerificationTable(w io.Writer, verifications []Verification) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tInput\tPart\tWant\tGot\tResult")
+	failures := 0
+	for _, v := range verifications {
+		status := "ok"
+		if v.Err != nil {
+			status = "ERROR"
+		} else if !v.Passed() {
+			status = "MISMATCH"
+		}
+		if !v.Passed() {
+			failures++
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", v.Day, v.Input, v.Part, v.Want, v.Got, status)
+	}
+	tw.Flush()
+	for _, v := range verifications {
+		if v.Err != nil {
+			fmt.Fprintf(w, "Day %d %s part %d: %s\n", v.Day, v.Input, v.Part, v.Err)
+		}
+	}
+	fmt.Fprintf(w, "%d of %d answers verified\n", len(verifications)-failures, len(verifications))
+}
+
+// runVerify verifies the given day of year, or every day of year if day is not
+// positive. Only the parts run by opts are verified. It returns true if every
+// recorded answer matched.
+func runVerify(year, day int, opts RunOptions) bool {
+	days := util.DaysInYear(year)
+	if day > 0 {
+		info, ok := util.LookupDay(year, day)
+		if !ok {
+			fmt.Printf("No solution found for day %d of %d\n", day, year)
+			return false
+		}
+		days = []util.DayInfo{info}
+	}
+	return verifyDays(os.Stdout, days, opts)
+}
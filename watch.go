@@ -0,0 +1,202 @@
+package main
+
+import (
+	"advent/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultPollInterval is how often -watch checks for changes.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// fileState is what is compared to tell whether a watched file has changed.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// runWatch runs the day chosen by flags, and again every time a file in the
+// day's directory, its input under the input root, or the input given by -i,
+// changes. Each run rebuilds the runner with go build, so that changes to the
+// solution are picked up, and shows the new answers alongside the previous
+// ones. It stops on an interrupt.
+func runWatch(out io.Writer, flags Flags) bool {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	dir, err := os.MkdirTemp("", "advent-watch-*")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating build directory: %s\n", err)
+		return false
+	}
+	defer os.RemoveAll(dir)
+	binary := filepath.Join(dir, "advent")
+	paths := []string{util.DayDir(flags.Year, flags.Day)}
+	if flags.InputRoot != "" {
+		info := util.DayInfo{Year: flags.Year, Day: flags.Day}
+		paths = append(paths, getNamedFilepath(flags.InputRoot, info, InputFileName))
+	}
+	if flags.Input != "" {
+		paths = append(paths, flags.Input)
+	}
+
+	ticker := time.NewTicker(flags.Poll)
+	defer ticker.Stop()
+	var snapshot map[string]fileState
+	var previous []PartRecord
+	for {
+		current, err := snapshotFiles(paths)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking for changes: %s\n", err)
+		} else if !maps.Equal(current, snapshot) {
+			snapshot = current
+			fmt.Fprintf(out, "[%s] Building and running day %d\n", time.Now().Format(time.TimeOnly), flags.Day)
+			if records, ok := buildAndRun(ctx, out, binary, watchArgs(flags)); ok {
+				printWatchResults(out, records, previous)
+				previous = records
+			}
+			fmt.Fprintln(out, "Watching for changes, interrupt to stop")
+		}
+		select {
+		case <-ctx.Done():
+			return true
+		case <-ticker.C:
+		}
+	}
+}
+
+// snapshotFiles returns the state of every file under paths, keyed by path.
+// Paths that don't exist are left out.
+func snapshotFiles(paths []string) (map[string]fileState, error) {
+	snapshot := make(map[string]fileState)
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			snapshot[path] = fileState{info.Size(), info.ModTime()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// watchArgs returns the arguments running the day chosen by flags once, with
+// its results written as JSON.
+func watchArgs(flags Flags) []string {
+	args := []string{
+		"-y", strconv.Itoa(flags.Year),
+		"-d", strconv.Itoa(flags.Day),
+		"-p", strconv.Itoa(flags.Part),
+		"-format", JSONFormat,
+		"-timeout", flags.Timeout.String(),
+	}
+	switch {
+	case flags.Input != "":
+		args = append(args, "-i", flags.Input)
+	case flags.Example != "":
+		args = append(args, "-e", flags.Example)
+	case flags.Test:
+		args = append(args, "-t")
+	}
+	if flags.InputRoot != "" {
+		args = append(args, "-inputs", flags.InputRoot)
+	}
+	if flags.Verbose {
+		args = append(args, "-v")
+	}
+	for _, name := range slices.Sorted(maps.Keys(flags.Params)) {
+		args = append(args, "-param", fmt.Sprintf("%s=%d", name, flags.Params[name]))
+	}
+	return args
+}
+
+// buildAndRun builds the runner into binary, and runs it with args, returning
+// the records it writes. A failed build is printed to out, and returns false.
+func buildAndRun(ctx context.Context, out io.Writer, binary string, args []string) ([]PartRecord, bool) {
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, ".")
+	if output, err := build.CombinedOutput(); err != nil {
+		fmt.Fprintf(out, "Build failed: %s\n%s", err, output)
+		return nil, false
+	}
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	// the runner exits with an error when a part fails, which its records
+	// report, so only a run without records has failed
+	records, parseErr := parseRecords(output)
+	if len(records) == 0 {
+		if err == nil {
+			err = parseErr
+		}
+		fmt.Fprintf(out, "Run failed: %s\n", err)
+		return nil, false
+	}
+	return records, true
+}
+
+// parseRecords parses the JSON records written by the runner, one per line.
+func parseRecords(data []byte) ([]PartRecord, error) {
+	records := make([]PartRecord, 0)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var record PartRecord
+		if err := decoder.Decode(&record); err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// printWatchResults writes the answer or error for each part in records to w,
+// alongside the answer from the matching part in previous, if there is one.
+func printWatchResults(w io.Writer, records, previous []PartRecord) {
+	before := make(map[int]PartRecord)
+	for _, record := range previous {
+		before[record.Part] = record
+	}
+	for _, record := range records {
+		result := recordResult(record)
+		comparison := ""
+		if prev, ok := before[record.Part]; ok {
+			if was := recordResult(prev); was == result {
+				comparison = ", unchanged"
+			} else {
+				comparison = ", was " + was
+			}
+		}
+		fmt.Fprintf(w, "Part %d: %s (%s%s)\n", record.Part, result,
+			formatDuration(time.Duration(record.PartTime)), comparison)
+	}
+}
+
+// recordResult returns the answer in record, or its error.
+func recordResult(record PartRecord) string {
+	if record.Error != "" {
+		return "error: " + record.Error
+	}
+	return record.Answer
+}
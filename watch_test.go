@@ -0,0 +1,60 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "files", "test.txt")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("1 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := snapshotFiles([]string{dir, filepath.Join(dir, "missing.txt")})
+	if err != nil {
+		t.Fatalf("snapshotFiles() error = %v", err)
+	}
+	if got := slices.Collect(maps.Keys(before)); !slices.Equal(got, []string{path}) {
+		t.Errorf("snapshotFiles() has files %v, want %v", got, []string{path})
+	}
+	same, _ := snapshotFiles([]string{dir})
+	if !maps.Equal(before, same) {
+		t.Errorf("snapshotFiles() changed without any change to the files")
+	}
+	if err := os.WriteFile(path, []byte("1 2\n3 4\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := snapshotFiles([]string{dir})
+	if maps.Equal(before, after) {
+		t.Errorf("snapshotFiles() did not change after a file was written")
+	}
+}
+
+func TestWatchArgs(t *testing.T) {
+	flags := Flags{Year: 2024, Day: 15, Part: 2, Example: "test2", Timeout: 30 * time.Second}
+	got := strings.Join(watchArgs(flags), " ")
+	want := "-y 2024 -d 15 -p 2 -format json -timeout 30s -e test2"
+	if got != want {
+		t.Errorf("watchArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintWatchResults(t *testing.T) {
+	previous := []PartRecord{{Part: 1, Answer: "11"}, {Part: 2, Error: "not implemented"}}
+	records := []PartRecord{{Part: 1, Answer: "11"}, {Part: 2, Answer: "31"}}
+	var out strings.Builder
+	printWatchResults(&out, records, previous)
+	want := "Part 1: 11 (0s, unchanged)\nPart 2: 31 (0s, was error: not implemented)\n"
+	if out.String() != want {
+		t.Errorf("printWatchResults() wrote %q, want %q", out.String(), want)
+	}
+}